@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,46 +14,212 @@ import (
 	"go.uber.org/zap"
 
 	_ "github.com/suraif16/webpage-analyzer/docs"
-	"github.com/suraif16/webpage-analyzer/internal/config"
+	appconfig "github.com/suraif16/webpage-analyzer/internal/config"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
 	"github.com/suraif16/webpage-analyzer/internal/core/services"
+	"github.com/suraif16/webpage-analyzer/internal/grpcapi"
 	"github.com/suraif16/webpage-analyzer/internal/handlers"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/health"
 	httpClient "github.com/suraif16/webpage-analyzer/internal/infrastructure/http/client"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/metrics"
 	"github.com/suraif16/webpage-analyzer/internal/infrastructure/parser"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/store/memory"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/tracing"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
 	"github.com/suraif16/webpage-analyzer/internal/middleware"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	// Initialize logger
-	logger, _ := zap.NewProduction()
+	args := os.Args[1:]
+	config, err := appconfig.Load(args)
+	if err != nil {
+		logger, _ := zap.NewProduction()
+		logger.Fatal("Cannot load config:", zap.Error(err))
+	}
+
+	// Initialize logger; the level can change on reload
+	logLevel, err := zap.ParseAtomicLevel(config.LogLevel)
+	if err != nil {
+		logger, _ := zap.NewProduction()
+		logger.Fatal("Cannot parse log level:", zap.Error(err))
+	}
+	logger, err := logging.NewAtLevel(logLevel)
+	if err != nil {
+		logger, _ = zap.NewProduction()
+		logger.Fatal("Cannot create logger:", zap.Error(err))
+	}
 	defer logger.Sync()
 
-	config, err := config.LoadConfig()
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    config.TracingExporter,
+		Endpoint:    config.TracingEndpoint,
+		FilePath:    config.TracingFile,
+		ServiceName: "webpage-analyzer",
+		SampleRatio: config.TracingSampleRatio,
+	}, logger)
 	if err != nil {
-		logger.Fatal("Cannot load config:", zap.Error(err))
+		logger.Fatal("failed to set up tracing", zap.Error(err))
 	}
 
 	// Initialize dependencies
-	httpClient := httpClient.NewHTTPClient(config.RequestTimeout, logger)
-	htmlParser := parser.NewHTMLParser(logger)
-	analyzerService := services.NewAnalyzerService(httpClient, htmlParser, logger)
+	var proxyURL *url.URL
+	if config.ProxyURL != "" {
+		// Validated by config.Load
+		proxyURL, _ = domain.ParseProxyURL(config.ProxyURL)
+		logger.Info("sending outbound requests through proxy",
+			zap.String("proxy", proxyURL.Redacted()),
+			zap.String("no_proxy", config.NoProxy))
+	}
+
+	baseClient := httpClient.NewHTTPClient(config.RequestTimeout, logger,
+		httpClient.WithMaxRedirects(config.MaxRedirects),
+		httpClient.WithProxy(proxyURL, config.NoProxy),
+		httpClient.WithAllowedProxies(config.AllowedProxies),
+		httpClient.WithAllowedHosts(config.EgressAllowedHosts),
+		httpClient.WithMaxBodySize(config.MaxBodySize),
+		httpClient.WithCache(config.CacheMaxEntries, config.CacheMaxBytes),
+		httpClient.WithPoliteness(httpClient.PolitenessPolicy{
+			RequestsPerSecond: config.HostRequestsPerSecond,
+			Burst:             config.HostBurst,
+			MaxConnsPerHost:   config.HostMaxConnections,
+			RespectCrawlDelay: config.RespectCrawlDelay,
+		}))
+	retryingClient := httpClient.NewRetryingClient(tracing.TraceClient(baseClient),
+		retryPolicy(config),
+		httpClient.BreakerPolicy{
+			FailureThreshold: config.BreakerFailureThreshold,
+			Cooldown:         config.BreakerCooldown,
+		},
+		logger)
+	var outboundClient ports.HTTPClient = retryingClient
+	var htmlParser ports.HTMLParser = parser.NewHTMLParser(logger)
+	var appMetrics *metrics.Metrics
+	if config.MetricsEnabled {
+		appMetrics = metrics.New()
+		appMetrics.RegisterQueue(baseClient)
+		outboundClient = metrics.InstrumentClient(outboundClient, appMetrics)
+		htmlParser = metrics.InstrumentParser(htmlParser, appMetrics)
+	}
+	analyzerService := services.NewAnalyzerService(outboundClient, htmlParser,
+		services.LinkCheckPolicy{
+			MaxLinks:    config.LinkCheckLimit,
+			Concurrency: config.LinkCheckConcurrency,
+		},
+		logger)
+	if appMetrics != nil {
+		analyzerService = metrics.InstrumentAnalyzer(analyzerService, appMetrics)
+	}
 	analyzerHandler := handlers.NewAnalyzerHandler(analyzerService, logger)
+	debugHandler := handlers.NewDebugHandler(retryingClient, baseClient)
+
+	var keyStore ports.APIKeyStore
+	switch config.APIKeyStore {
+	case "memory":
+		keyStore = memory.NewAPIKeyStore()
+	default:
+		logger.Fatal("unsupported API_KEY_STORE", zap.String("store", config.APIKeyStore))
+	}
+	apiKeyService := services.NewAPIKeyService(keyStore, services.APIKeyDefaults{
+		RateLimitPerMinute: config.APIKeyRateLimit,
+		DailyQuota:         config.APIKeyDailyQuota,
+	}, logger)
+	if config.AdminAPIKey != "" {
+		if _, err := apiKeyService.Register(context.Background(), "bootstrap admin", config.AdminAPIKey, domain.RoleAdmin); err != nil {
+			logger.Fatal("failed to register admin API key", zap.Error(err))
+		}
+	}
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService, logger)
+
+	healthService := services.NewHealthService(config.HealthCheckTimeout)
+	healthService.RegisterReadiness(health.NewStoreCheck(keyStore))
+	healthService.RegisterReadiness(health.NewQueueCheck(baseClient, config.HealthMaxQueued))
+	if config.HealthEgressTarget != "" {
+		healthService.RegisterReadiness(health.NewEgressCheck(config.HealthEgressTarget, config.HealthEgressInterval))
+	}
+	healthHandler := handlers.NewHealthHandler(healthService)
+
+	var reloadObserver appconfig.ReloadObserver
+	if appMetrics != nil {
+		reloadObserver = appMetrics
+	}
+	reloader := appconfig.NewReloader(config, args, reloadObserver, logger)
+	configHandler := handlers.NewConfigHandler(reloader)
 
 	// Setup Gin
+	gin.SetMode(config.GinMode)
 	r := gin.New()
+	if err := r.SetTrustedProxies(config.TrustedProxies); err != nil {
+		logger.Fatal("invalid TRUSTED_PROXIES", zap.Error(err))
+	}
 	r.Use(gin.Recovery())
+	r.Use(otelgin.Middleware("webpage-analyzer"))
+	r.Use(middleware.RequestID(logger))
 	r.Use(middleware.Logger(logger))
-	r.Use(middleware.CORS())
+	if appMetrics != nil {
+		r.Use(middleware.Metrics(appMetrics))
+		r.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+	}
+	cors := middleware.NewCORSPolicy(corsConfig(config), logger)
+	r.Use(cors.Handler)
 
 	// Swagger route
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Routes
-	r.POST("/analyze", analyzerHandler.Analyze)
+	// Rate limits run after authentication so that keys are limited by key
+	// rather than by the address they connect from. Gin only applies group
+	// middleware to routes registered after it, so every Use comes first.
+	// Failed authentication attempts are limited by address ahead of Auth.
+	authFailureLimit := middleware.NewRateLimiter(authFailureRateLimitPolicy(config), logger)
+	rateLimit := middleware.NewRateLimiter(rateLimitPolicy(config), logger)
+	analyzeRateLimit := middleware.NewRateLimiter(analyzeRateLimitPolicy(config), logger)
+
+	api := r.Group("/")
+	if config.AuthEnabled {
+		api.Use(authFailureLimit.FailureHandler, middleware.Auth(apiKeyService, logger), middleware.KeyLimit(apiKeyService, logger))
+	} else {
+		logger.Warn("authentication is disabled, the API is open to anyone who can reach it and the admin and debug endpoints are not served")
+	}
+	api.Use(rateLimit.Handler)
+	api.POST("/analyze", analyzeRateLimit.Handler, analyzerHandler.Analyze)
+	api.POST("/analyze/html", analyzeRateLimit.Handler, analyzerHandler.AnalyzeHTML)
+
+	// Without authentication there is no admin role to restrict these to
+	if config.AuthEnabled {
+		admin := r.Group("/", authFailureLimit.FailureHandler, middleware.Auth(apiKeyService, logger), middleware.KeyLimit(apiKeyService, logger), middleware.RequireRole(domain.RoleAdmin), rateLimit.Handler)
+		admin.POST("/admin/api-keys", apiKeyHandler.Create)
+		admin.GET("/admin/api-keys", apiKeyHandler.List)
+		admin.DELETE("/admin/api-keys/:id", apiKeyHandler.Revoke)
+		admin.GET("/admin/config", configHandler.Config)
+		admin.GET("/debug/circuit-breakers", debugHandler.CircuitBreakers)
+		admin.GET("/debug/host-limits", debugHandler.HostLimits)
+	}
+
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
+	r.GET("/livez", healthHandler.Livez)
+	r.GET("/readyz", healthHandler.Readyz)
+
+	// Swap in settings that can change at runtime on SIGHUP or file change
+	reloader.OnReload(func(c *appconfig.Config) {
+		_ = logLevel.UnmarshalText([]byte(c.LogLevel))
+		baseClient.SetTimeout(c.RequestTimeout)
+		baseClient.SetMaxRedirects(c.MaxRedirects)
+		baseClient.SetAllowedHosts(c.EgressAllowedHosts)
+		retryingClient.SetRetryPolicy(retryPolicy(c))
+		cors.Update(corsConfig(c))
+		rateLimit.Update(rateLimitPolicy(c))
+		analyzeRateLimit.Update(analyzeRateLimitPolicy(c))
+		authFailureLimit.Update(authFailureRateLimitPolicy(c))
+	})
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	reloader.Watch(watchCtx)
 
 	// Server configuration
 	srv := &http.Server{
@@ -67,11 +235,50 @@ func main() {
 		}
 	}()
 
+	// The gRPC API shares the analyzer and API keys with the REST API
+	var grpcServer *grpc.Server
+	if config.GRPCPort != "" {
+		grpcOpts := grpcapi.Options{
+			// Shared with the REST API, so a client has one budget across both
+			RateLimit:        rateLimit,
+			AnalyzeRateLimit: analyzeRateLimit,
+			Batch: grpcapi.BatchPolicy{
+				MaxSize:     config.GRPCMaxBatchSize,
+				Concurrency: config.GRPCBatchConcurrency,
+			},
+			Reflection: config.GRPCReflection,
+		}
+		if config.AuthEnabled {
+			grpcOpts.Keys = apiKeyService
+			grpcOpts.AuthFailureLimit = authFailureLimit
+		}
+		grpcServer = grpcapi.NewGRPCServer(analyzerService, grpcOpts, logger)
+
+		lis, err := net.Listen("tcp", ":"+config.GRPCPort)
+		if err != nil {
+			logger.Fatal("failed to listen for gRPC", zap.Error(err))
+		}
+		logger.Info("Starting gRPC server on port " + config.GRPCPort)
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Fatal("failed to start gRPC server", zap.Error(err))
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
+	stopWatching()
+
+	// Fail readiness first and give load balancers time to notice before
+	// new connections are refused
+	healthService.Drain()
+	logger.Info("draining before shutdown", zap.Duration("delay", config.ShutdownDrainDelay))
+	time.Sleep(config.ShutdownDrainDelay)
+
 	logger.Info("shutting down server...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -80,6 +287,70 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Fatal("server forced to shutdown:", zap.Error(err))
 	}
+	if grpcServer != nil {
+		stopGRPC(ctx, grpcServer)
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("failed to flush traces", zap.Error(err))
+	}
 
 	logger.Info("server exited properly")
 }
+
+// stopGRPC lets in-flight calls finish, cancelling them when ctx is done
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+func retryPolicy(c *appconfig.Config) httpClient.RetryPolicy {
+	return httpClient.RetryPolicy{
+		MaxAttempts: c.RetryMaxAttempts,
+		BaseDelay:   c.RetryBaseDelay,
+		MaxDelay:    c.RetryMaxDelay,
+		Timeout:     c.RequestTimeout,
+	}
+}
+
+func corsConfig(c *appconfig.Config) middleware.CORSConfig {
+	return middleware.CORSConfig{
+		AllowedOrigins:   c.AllowedOrigins,
+		AllowedMethods:   c.CORSAllowedMethods,
+		AllowedHeaders:   c.CORSAllowedHeaders,
+		ExposedHeaders:   c.CORSExposedHeaders,
+		AllowCredentials: c.CORSAllowCredentials,
+		MaxAge:           c.CORSMaxAge,
+	}
+}
+
+func rateLimitPolicy(c *appconfig.Config) middleware.RateLimitPolicy {
+	return middleware.RateLimitPolicy{
+		Name:              "default",
+		RequestsPerMinute: c.RateLimitPerMinute,
+		Burst:             c.RateLimitBurst,
+	}
+}
+
+func analyzeRateLimitPolicy(c *appconfig.Config) middleware.RateLimitPolicy {
+	return middleware.RateLimitPolicy{
+		Name:              "analyze",
+		RequestsPerMinute: c.AnalyzeRateLimitPerMinute,
+		Burst:             c.AnalyzeRateLimitBurst,
+	}
+}
+
+func authFailureRateLimitPolicy(c *appconfig.Config) middleware.RateLimitPolicy {
+	return middleware.RateLimitPolicy{
+		Name:              "auth_failure",
+		RequestsPerMinute: c.AuthFailureRateLimitPerMinute,
+		Burst:             c.AuthFailureRateLimitBurst,
+	}
+}
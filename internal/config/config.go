@@ -1,33 +1,332 @@
+// Package config resolves the service settings. Every setting can come from
+// several sources; later sources override earlier ones:
+//
+//  1. the defaults in Default
+//  2. a YAML or TOML file named by the --config flag or CONFIG_FILE
+//  3. the .env file in the working directory
+//  4. environment variables, named like the setting (REQUEST_TIMEOUT)
+//  5. command-line flags, named like the setting in lower case with dashes
+//     (--request-timeout)
+//
+// In files, setting names are case-insensitive (request_timeout: 30s).
 package config
 
 import (
-	"github.com/spf13/viper"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
 	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
+// Config holds every setting. Fields tagged secret are masked by
+// EffectiveConfig; fields tagged reload take effect on Reloader.Reload, the
+// others only at startup.
 type Config struct {
 	Port           string        `mapstructure:"PORT"`
 	GinMode        string        `mapstructure:"GIN_MODE"`
-	AllowedOrigins string        `mapstructure:"ALLOWED_ORIGINS"`
-	RequestTimeout time.Duration `mapstructure:"REQUEST_TIMEOUT"`
-}
+	LogLevel       string        `mapstructure:"LOG_LEVEL" reload:"true"`
+	AllowedOrigins []string      `mapstructure:"ALLOWED_ORIGINS" reload:"true"`
+	RequestTimeout time.Duration `mapstructure:"REQUEST_TIMEOUT" reload:"true"`
+	MaxRedirects   int           `mapstructure:"MAX_REDIRECTS" reload:"true"`
+	MaxBodySize    int64         `mapstructure:"MAX_BODY_SIZE"`
 
-func LoadConfig() (*Config, error) {
-	viper.SetConfigFile(".env")
-	viper.AutomaticEnv()
+	RetryMaxAttempts        int           `mapstructure:"RETRY_MAX_ATTEMPTS" reload:"true"`
+	RetryBaseDelay          time.Duration `mapstructure:"RETRY_BASE_DELAY" reload:"true"`
+	RetryMaxDelay           time.Duration `mapstructure:"RETRY_MAX_DELAY" reload:"true"`
+	BreakerFailureThreshold int           `mapstructure:"BREAKER_FAILURE_THRESHOLD"`
+	BreakerCooldown         time.Duration `mapstructure:"BREAKER_COOLDOWN"`
+
+	HostRequestsPerSecond float64 `mapstructure:"HOST_REQUESTS_PER_SECOND"`
+	HostBurst             int     `mapstructure:"HOST_BURST"`
+	HostMaxConnections    int     `mapstructure:"HOST_MAX_CONNECTIONS"`
+	RespectCrawlDelay     bool    `mapstructure:"RESPECT_CRAWL_DELAY"`
+
+	// LinkCheckLimit is the largest number of distinct links checked per
+	// analyzed page; 0 disables link checks
+	LinkCheckLimit       int `mapstructure:"LINK_CHECK_LIMIT"`
+	LinkCheckConcurrency int `mapstructure:"LINK_CHECK_CONCURRENCY"`
+
+	// ProxyURL is an http, https or socks5 proxy for outbound requests,
+	// optionally with credentials in its user info
+	ProxyURL string `mapstructure:"PROXY_URL"`
+	NoProxy  string `mapstructure:"NO_PROXY"`
+	// AllowedProxies lists the proxies a request may name in its proxy
+	// option; when empty, requests cannot choose a proxy
+	AllowedProxies []string `mapstructure:"ALLOWED_PROXIES"`
+	// EgressAllowedHosts lists the hosts, with their subdomains, that pages
+	// and links may be fetched from; when empty any host may be contacted
+	EgressAllowedHosts []string `mapstructure:"EGRESS_ALLOWED_HOSTS" reload:"true"`
 
-	config := &Config{
-		// Default values
+	// CacheMaxEntries of 0 disables the response cache
+	CacheMaxEntries int   `mapstructure:"CACHE_MAX_ENTRIES"`
+	CacheMaxBytes   int64 `mapstructure:"CACHE_MAX_BYTES"`
+
+	// AllowedOrigins and the CORS lists below are comma separated; origins may
+	// be exact, wildcard subdomains like https://*.example.com, or *
+	CORSAllowedMethods   []string      `mapstructure:"CORS_ALLOWED_METHODS" reload:"true"`
+	CORSAllowedHeaders   []string      `mapstructure:"CORS_ALLOWED_HEADERS" reload:"true"`
+	CORSExposedHeaders   []string      `mapstructure:"CORS_EXPOSED_HEADERS" reload:"true"`
+	CORSAllowCredentials bool          `mapstructure:"CORS_ALLOW_CREDENTIALS" reload:"true"`
+	CORSMaxAge           time.Duration `mapstructure:"CORS_MAX_AGE" reload:"true"`
+
+	// MetricsEnabled serves Prometheus metrics on /metrics
+	MetricsEnabled bool `mapstructure:"METRICS_ENABLED"`
+
+	// TracingExporter is none, stdout, file or otlp
+	TracingExporter    string  `mapstructure:"TRACING_EXPORTER"`
+	TracingEndpoint    string  `mapstructure:"TRACING_ENDPOINT"`
+	TracingFile        string  `mapstructure:"TRACING_FILE"`
+	TracingSampleRatio float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+
+	HealthCheckTimeout time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT"`
+	// HealthMaxQueued is the number of queued outbound requests above which
+	// the service reports itself not ready; 0 disables the check
+	HealthMaxQueued int64 `mapstructure:"HEALTH_MAX_QUEUED"`
+	// HealthEgressTarget is a host:port dialled to verify outbound DNS and
+	// connectivity; empty disables the check
+	HealthEgressTarget   string        `mapstructure:"HEALTH_EGRESS_TARGET"`
+	HealthEgressInterval time.Duration `mapstructure:"HEALTH_EGRESS_INTERVAL"`
+	// ShutdownDrainDelay is how long readiness fails before the server stops
+	ShutdownDrainDelay time.Duration `mapstructure:"SHUTDOWN_DRAIN_DELAY"`
+
+	AuthEnabled bool `mapstructure:"AUTH_ENABLED"`
+	// APIKeyStore selects where API keys are kept; only "memory" is supported
+	APIKeyStore string `mapstructure:"API_KEY_STORE"`
+	// AdminAPIKey is registered as an admin key at startup
+	AdminAPIKey      string `mapstructure:"ADMIN_API_KEY" secret:"true"`
+	APIKeyRateLimit  int    `mapstructure:"API_KEY_RATE_LIMIT"`
+	APIKeyDailyQuota int    `mapstructure:"API_KEY_DAILY_QUOTA"`
+
+	// Inbound limits per API key or client IP; a rate of 0 disables them.
+	// The analyze limits apply to /analyze and /analyze/html on top of the
+	// general ones.
+	RateLimitPerMinute        int `mapstructure:"RATE_LIMIT_PER_MINUTE" reload:"true"`
+	RateLimitBurst            int `mapstructure:"RATE_LIMIT_BURST" reload:"true"`
+	AnalyzeRateLimitPerMinute int `mapstructure:"ANALYZE_RATE_LIMIT_PER_MINUTE" reload:"true"`
+	AnalyzeRateLimitBurst     int `mapstructure:"ANALYZE_RATE_LIMIT_BURST" reload:"true"`
+	// Failed authentication attempts allowed per client IP; a rate of 0
+	// disables the limit
+	AuthFailureRateLimitPerMinute int `mapstructure:"AUTH_FAILURE_RATE_LIMIT_PER_MINUTE" reload:"true"`
+	AuthFailureRateLimitBurst     int `mapstructure:"AUTH_FAILURE_RATE_LIMIT_BURST" reload:"true"`
+	// TrustedProxies lists the proxies whose X-Forwarded-For is used to find
+	// the client IP; when empty the connection address is used
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+
+	// GRPCPort serves the gRPC API; empty disables it. GRPCReflection exposes
+	// the reflection service for tools such as grpcurl.
+	GRPCPort             string `mapstructure:"GRPC_PORT"`
+	GRPCReflection       bool   `mapstructure:"GRPC_REFLECTION"`
+	GRPCMaxBatchSize     int    `mapstructure:"GRPC_MAX_BATCH_SIZE"`
+	GRPCBatchConcurrency int    `mapstructure:"GRPC_BATCH_CONCURRENCY"`
+}
+
+// Default returns the settings used when no source overrides them
+func Default() *Config {
+	return &Config{
 		Port:           "8080",
 		GinMode:        "release",
-		AllowedOrigins: "http://localhost:3000",
+		LogLevel:       "info",
+		AllowedOrigins: []string{"http://localhost:3000"},
 		RequestTimeout: 30 * time.Second,
+		MaxRedirects:   10,
+		MaxBodySize:    10 << 20,
+
+		RetryMaxAttempts:        3,
+		RetryBaseDelay:          200 * time.Millisecond,
+		RetryMaxDelay:           5 * time.Second,
+		BreakerFailureThreshold: 5,
+		BreakerCooldown:         30 * time.Second,
+
+		HostRequestsPerSecond: 5,
+		HostBurst:             10,
+		HostMaxConnections:    4,
+		RespectCrawlDelay:     true,
+
+		LinkCheckLimit:       100,
+		LinkCheckConcurrency: 8,
+
+		CacheMaxEntries: 1000,
+		CacheMaxBytes:   64 << 20,
+
+		CORSAllowedMethods: []string{"GET", "POST", "DELETE", "OPTIONS"},
+		CORSAllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-API-Key", "X-Request-ID"},
+		CORSExposedHeaders: []string{"X-Request-ID", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"},
+		CORSMaxAge:         10 * time.Minute,
+
+		MetricsEnabled: true,
+
+		TracingExporter:    "none",
+		TracingFile:        "traces.jsonl",
+		TracingSampleRatio: 1,
+
+		HealthCheckTimeout:   2 * time.Second,
+		HealthMaxQueued:      100,
+		HealthEgressInterval: 30 * time.Second,
+		ShutdownDrainDelay:   5 * time.Second,
+
+		AuthEnabled:      false,
+		APIKeyStore:      "memory",
+		APIKeyRateLimit:  60,
+		APIKeyDailyQuota: 1000,
+
+		RateLimitPerMinute:        120,
+		RateLimitBurst:            30,
+		AnalyzeRateLimitPerMinute: 20,
+		AnalyzeRateLimitBurst:     5,
+
+		AuthFailureRateLimitPerMinute: 10,
+		AuthFailureRateLimitBurst:     5,
+
+		GRPCPort:             "9090",
+		GRPCReflection:       true,
+		GRPCMaxBatchSize:     20,
+		GRPCBatchConcurrency: 4,
+	}
+}
+
+// LoadConfig resolves the settings from files and the environment, without
+// reading command-line flags
+func LoadConfig() (*Config, error) {
+	return Load(nil)
+}
+
+// Load resolves the settings from every source, with args as the
+// command-line flags, and validates the result. Unreadable files and invalid
+// values are reported rather than replaced by defaults.
+func Load(args []string) (*Config, error) {
+	config := Default()
+	v := viper.New()
+	keys := settingKeys()
+
+	defaults := reflect.ValueOf(config).Elem()
+	for i, key := range keys {
+		v.SetDefault(key, defaults.Field(i).Interface())
+	}
+
+	flags, configFile := newFlagSet()
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configFile != "" {
+		v.SetConfigFile(*configFile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("read config file %s: %w", *configFile, err)
+		}
+	}
+
+	dotenv := viper.New()
+	dotenv.SetConfigFile(".env")
+	if err := dotenv.ReadInConfig(); err == nil {
+		if err := v.MergeConfigMap(dotenv.AllSettings()); err != nil {
+			return nil, fmt.Errorf("merge .env: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read .env: %w", err)
+	}
+
+	v.AutomaticEnv()
+
+	for _, key := range keys {
+		if f := flags.Lookup(flagName(key)); f.Changed {
+			v.Set(key, f.Value.String())
+		}
 	}
 
-	if err := viper.ReadInConfig(); err != nil {
-		return config, nil // Return default config if no .env file
+	if err := v.Unmarshal(config, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		splitList,
+	))); err != nil {
+		return nil, fmt.Errorf("decode settings: %w", err)
 	}
 
-	err := viper.Unmarshal(config)
-	return config, err
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// EffectiveConfig returns every setting by name with secrets and proxy
+// credentials masked, for display on the admin API
+func (c *Config) EffectiveConfig() map[string]any {
+	settings := make(map[string]any)
+	value := reflect.ValueOf(c).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key := field.Tag.Get("mapstructure")
+		v := value.Field(i).Interface()
+
+		switch {
+		case field.Tag.Get("secret") == "true":
+			if v != "" {
+				v = "REDACTED"
+			}
+		case key == "PROXY_URL":
+			if u, err := url.Parse(c.ProxyURL); err == nil {
+				v = u.Redacted()
+			}
+		case key == "ALLOWED_PROXIES":
+			proxies := make([]string, len(c.AllowedProxies))
+			for i, proxy := range c.AllowedProxies {
+				proxies[i] = proxy
+				if u, err := url.Parse(proxy); err == nil {
+					proxies[i] = u.Redacted()
+				}
+			}
+			v = proxies
+		}
+		if d, ok := v.(time.Duration); ok {
+			v = d.String()
+		}
+		settings[key] = v
+	}
+	return settings
+}
+
+// newFlagSet defines --config and one flag per setting
+func newFlagSet() (*pflag.FlagSet, *string) {
+	flags := pflag.NewFlagSet("webpage-analyzer", pflag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML configuration file")
+	for _, key := range settingKeys() {
+		flags.String(flagName(key), "", "overrides "+key)
+	}
+	return flags, configFile
+}
+
+// settingKeys lists the setting names in field order
+func settingKeys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, t.NumField())
+	for i := range keys {
+		keys[i] = t.Field(i).Tag.Get("mapstructure")
+	}
+	return keys
+}
+
+// flagName turns REQUEST_TIMEOUT into request-timeout
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+// splitList decodes comma separated settings into string slices, trimming
+// spaces and dropping empty items
+func splitList(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf([]string{}) {
+		return data, nil
+	}
+	var items []string
+	for _, item := range strings.Split(data.(string), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
 }
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// fileSettleDelay lets writers finish before a changed file is read, so that
+// a truncated file is not loaded halfway through being rewritten
+const fileSettleDelay = 200 * time.Millisecond
+
+// Reload outcomes
+const (
+	ReloadApplied   = "applied"
+	ReloadUnchanged = "unchanged"
+	ReloadFailed    = "failed"
+)
+
+// ReloadObserver records the outcome of every reload
+type ReloadObserver interface {
+	ObserveReload(trigger, outcome string)
+}
+
+// Reloader re-resolves the settings from every source and passes those tagged
+// reload to its listeners. Other settings keep their startup value until the
+// process restarts.
+type Reloader struct {
+	args     []string
+	observer ReloadObserver
+	logger   *zap.Logger
+
+	mu        sync.Mutex
+	current   atomic.Pointer[Config]
+	listeners []func(*Config)
+}
+
+// NewReloader creates a reloader for settings loaded from args. observer may
+// be nil.
+func NewReloader(current *Config, args []string, observer ReloadObserver, logger *zap.Logger) *Reloader {
+	r := &Reloader{
+		args:     args,
+		observer: observer,
+		logger:   logger,
+	}
+	r.current.Store(current)
+	return r
+}
+
+// Current returns the settings in effect
+func (r *Reloader) Current() *Config {
+	return r.current.Load()
+}
+
+// EffectiveConfig returns the settings in effect, masked for display
+func (r *Reloader) EffectiveConfig() map[string]any {
+	return r.Current().EffectiveConfig()
+}
+
+// OnReload registers fn to be called with the new settings after every reload
+// that changed at least one reloadable setting
+func (r *Reloader) OnReload(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, fn)
+}
+
+// Reload reads every source again. When any setting is invalid the reload is
+// rejected as a whole and the current settings stay in effect.
+func (r *Reloader) Reload(trigger string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.Current()
+	loaded, err := Load(r.args)
+	var next *Config
+	if err == nil {
+		next, err = current.merge(loaded)
+	}
+	if err != nil {
+		r.logger.Error("configuration reload rejected, keeping current settings",
+			zap.String("trigger", trigger),
+			zap.Error(err))
+		r.observe(trigger, ReloadFailed)
+		return err
+	}
+
+	changed, pending := current.diff(loaded)
+	if len(pending) > 0 {
+		r.logger.Warn("changed settings take effect only after a restart",
+			zap.String("trigger", trigger),
+			zap.Strings("settings", pending))
+	}
+	if len(changed) == 0 {
+		r.logger.Info("configuration reloaded without changes", zap.String("trigger", trigger))
+		r.observe(trigger, ReloadUnchanged)
+		return nil
+	}
+
+	r.current.Store(next)
+	for _, fn := range r.listeners {
+		fn(next)
+	}
+	r.logger.Info("configuration reloaded",
+		zap.String("trigger", trigger),
+		zap.Strings("changed", changed))
+	r.observe(trigger, ReloadApplied)
+	return nil
+}
+
+// Watch reloads on SIGHUP and whenever the config file or .env is written,
+// until ctx is done. Files that do not exist at startup are not watched.
+func (r *Reloader) Watch(ctx context.Context) {
+	flags, configFile := newFlagSet()
+	_ = flags.Parse(r.args) // already validated by Load
+
+	for _, path := range []string{*configFile, ".env"} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		// viper follows the file through atomic renames and the symlink swaps
+		// of Kubernetes ConfigMaps
+		reload := debounce(fileSettleDelay, func() {
+			if ctx.Err() == nil {
+				_ = r.Reload("file")
+			}
+		})
+		w := viper.New()
+		w.SetConfigFile(path)
+		w.OnConfigChange(func(fsnotify.Event) { reload() })
+		w.WatchConfig()
+		r.logger.Info("watching configuration file", zap.String("path", path))
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				_ = r.Reload("sighup")
+			}
+		}
+	}()
+}
+
+// debounce returns a function that calls fn once calls have stopped for d
+func debounce(d time.Duration, fn func()) func() {
+	var mu sync.Mutex
+	var timer *time.Timer
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(d, fn)
+	}
+}
+
+func (r *Reloader) observe(trigger, outcome string) {
+	if r.observer != nil {
+		r.observer.ObserveReload(trigger, outcome)
+	}
+}
+
+// merge returns c with the reloadable settings taken from next, validated
+// together since some settings constrain each other
+func (c *Config) merge(next *Config) (*Config, error) {
+	merged := *c
+	from := reflect.ValueOf(next).Elem()
+	to := reflect.ValueOf(&merged).Elem()
+	for i := 0; i < to.NumField(); i++ {
+		if to.Type().Field(i).Tag.Get("reload") == "true" {
+			to.Field(i).Set(from.Field(i))
+		}
+	}
+	if err := merged.Validate(); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// diff lists the reloadable settings that differ between c and next, and
+// separately the other settings that differ
+func (c *Config) diff(next *Config) (changed, pending []string) {
+	a := reflect.ValueOf(c).Elem()
+	b := reflect.ValueOf(next).Elem()
+	for i := 0; i < a.NumField(); i++ {
+		if reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			continue
+		}
+		field := a.Type().Field(i)
+		if field.Tag.Get("reload") == "true" {
+			changed = append(changed, field.Tag.Get("mapstructure"))
+		} else {
+			pending = append(pending, field.Tag.Get("mapstructure"))
+		}
+	}
+	return changed, pending
+}
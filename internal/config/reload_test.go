@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordingObserver []string
+
+func (r *recordingObserver) ObserveReload(trigger, outcome string) {
+	*r = append(*r, trigger+":"+outcome)
+}
+
+func TestReloader_Reload(t *testing.T) {
+	file := writeFile(t, "config.yaml", "request_timeout: 10s\nport: \"9000\"\n")
+	args := []string{"--config", file}
+	initial, err := Load(args)
+	require.NoError(t, err)
+
+	var observer recordingObserver
+	reloader := NewReloader(initial, args, &observer, zap.NewNop())
+	var reloaded []*Config
+	reloader.OnReload(func(c *Config) { reloaded = append(reloaded, c) })
+
+	// Unchanged sources do not notify listeners
+	require.NoError(t, reloader.Reload("sighup"))
+	assert.Empty(t, reloaded)
+
+	// Reloadable settings are applied; the port only changes on restart
+	require.NoError(t, os.WriteFile(file, []byte("request_timeout: 5s\nport: \"9001\"\nrate_limit_burst: 7\n"), 0o600))
+	require.NoError(t, reloader.Reload("file"))
+	require.Len(t, reloaded, 1)
+	assert.Equal(t, 5*time.Second, reloaded[0].RequestTimeout)
+	assert.Equal(t, 7, reloaded[0].RateLimitBurst)
+	assert.Equal(t, "9000", reloaded[0].Port)
+	assert.Same(t, reloaded[0], reloader.Current())
+	assert.Equal(t, "5s", reloader.EffectiveConfig()["REQUEST_TIMEOUT"])
+
+	// Invalid settings are rejected as a whole
+	require.NoError(t, os.WriteFile(file, []byte("request_timeout: 1s\nmax_redirects: -1\n"), 0o600))
+	assert.ErrorContains(t, reloader.Reload("file"), "MAX_REDIRECTS")
+	assert.Len(t, reloaded, 1)
+	assert.Equal(t, 5*time.Second, reloader.Current().RequestTimeout)
+
+	assert.Equal(t, recordingObserver{
+		"sighup:" + ReloadUnchanged,
+		"file:" + ReloadApplied,
+		"file:" + ReloadFailed,
+	}, observer)
+}
+
+func TestConfig_Diff(t *testing.T) {
+	current := Default()
+	next := Default()
+	next.AllowedOrigins = []string{"https://app.example.com"}
+	next.LogLevel = "debug"
+	next.MaxBodySize = 1 << 20
+
+	changed, pending := current.diff(next)
+
+	assert.Equal(t, []string{"LOG_LEVEL", "ALLOWED_ORIGINS"}, changed)
+	assert.Equal(t, []string{"MAX_BODY_SIZE"}, pending)
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap/zapcore"
+)
+
+// Validate checks every setting and reports all problems at once
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+	positive := func(key string, d time.Duration) {
+		if d <= 0 {
+			fail(key, "must be a positive duration, got %s", d)
+		}
+	}
+	nonNegative := func(key string, n float64) {
+		if n < 0 {
+			fail(key, "must not be negative, got %v", n)
+		}
+	}
+	oneOf := func(key, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		fail(key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+	}
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		fail("PORT", "must be a port number between 1 and 65535, got %q", c.Port)
+	}
+	if c.GRPCPort != "" {
+		if port, err := strconv.Atoi(c.GRPCPort); err != nil || port < 1 || port > 65535 {
+			fail("GRPC_PORT", "must be empty or a port number between 1 and 65535, got %q", c.GRPCPort)
+		} else if c.GRPCPort == c.Port {
+			fail("GRPC_PORT", "must differ from PORT (%s)", c.Port)
+		}
+	}
+	oneOf("GIN_MODE", c.GinMode, "debug", "release", "test")
+	if _, err := zapcore.ParseLevel(c.LogLevel); err != nil {
+		fail("LOG_LEVEL", "must be debug, info, warn or error, got %q", c.LogLevel)
+	}
+	for _, origin := range c.AllowedOrigins {
+		if err := validateOrigin(origin); err != nil {
+			fail("ALLOWED_ORIGINS", "%q %v", origin, err)
+		}
+	}
+
+	positive("REQUEST_TIMEOUT", c.RequestTimeout)
+	nonNegative("MAX_REDIRECTS", float64(c.MaxRedirects))
+	if c.MaxBodySize <= 0 {
+		fail("MAX_BODY_SIZE", "must be positive, got %d", c.MaxBodySize)
+	}
+
+	if c.RetryMaxAttempts < 1 {
+		fail("RETRY_MAX_ATTEMPTS", "must be at least 1, got %d", c.RetryMaxAttempts)
+	}
+	nonNegative("RETRY_BASE_DELAY", float64(c.RetryBaseDelay))
+	if c.RetryMaxDelay < c.RetryBaseDelay {
+		fail("RETRY_MAX_DELAY", "must not be shorter than RETRY_BASE_DELAY (%s), got %s", c.RetryBaseDelay, c.RetryMaxDelay)
+	}
+	nonNegative("BREAKER_FAILURE_THRESHOLD", float64(c.BreakerFailureThreshold))
+	nonNegative("BREAKER_COOLDOWN", float64(c.BreakerCooldown))
+
+	nonNegative("HOST_REQUESTS_PER_SECOND", c.HostRequestsPerSecond)
+	nonNegative("HOST_BURST", float64(c.HostBurst))
+	nonNegative("HOST_MAX_CONNECTIONS", float64(c.HostMaxConnections))
+	nonNegative("LINK_CHECK_LIMIT", float64(c.LinkCheckLimit))
+	if c.LinkCheckConcurrency < 1 {
+		fail("LINK_CHECK_CONCURRENCY", "must be at least 1, got %d", c.LinkCheckConcurrency)
+	}
+
+	if c.ProxyURL != "" {
+		if _, err := domain.ParseProxyURL(c.ProxyURL); err != nil {
+			fail("PROXY_URL", "%v", err)
+		}
+	}
+	for _, proxy := range c.AllowedProxies {
+		if _, err := domain.ParseProxyURL(proxy); err != nil {
+			fail("ALLOWED_PROXIES", "%v", err)
+		}
+	}
+	for _, host := range c.EgressAllowedHosts {
+		if strings.TrimLeft(host, "*.") == "" || strings.ContainsAny(host, "/@ ") {
+			fail("EGRESS_ALLOWED_HOSTS", "must be host names like example.com or *.example.com, got %q", host)
+		}
+	}
+	nonNegative("CACHE_MAX_ENTRIES", float64(c.CacheMaxEntries))
+	nonNegative("CACHE_MAX_BYTES", float64(c.CacheMaxBytes))
+	nonNegative("CORS_MAX_AGE", float64(c.CORSMaxAge))
+
+	oneOf("TRACING_EXPORTER", c.TracingExporter, "none", "stdout", "file", "otlp")
+	if c.TracingExporter == "file" && c.TracingFile == "" {
+		fail("TRACING_FILE", "is required by the file exporter")
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		fail("TRACING_SAMPLE_RATIO", "must be between 0 and 1, got %v", c.TracingSampleRatio)
+	}
+
+	positive("HEALTH_CHECK_TIMEOUT", c.HealthCheckTimeout)
+	nonNegative("HEALTH_MAX_QUEUED", float64(c.HealthMaxQueued))
+	if c.HealthEgressTarget != "" {
+		if _, _, err := net.SplitHostPort(c.HealthEgressTarget); err != nil {
+			fail("HEALTH_EGRESS_TARGET", "must be host:port, got %q", c.HealthEgressTarget)
+		}
+	}
+	nonNegative("HEALTH_EGRESS_INTERVAL", float64(c.HealthEgressInterval))
+	nonNegative("SHUTDOWN_DRAIN_DELAY", float64(c.ShutdownDrainDelay))
+
+	oneOf("API_KEY_STORE", c.APIKeyStore, "memory")
+	// Keys only live in memory, so without a bootstrap admin key no key could
+	// ever be created and every request would be refused
+	if c.AuthEnabled && c.AdminAPIKey == "" {
+		fail("ADMIN_API_KEY", "must be set when AUTH_ENABLED is true")
+	}
+	nonNegative("API_KEY_RATE_LIMIT", float64(c.APIKeyRateLimit))
+	nonNegative("API_KEY_DAILY_QUOTA", float64(c.APIKeyDailyQuota))
+	nonNegative("RATE_LIMIT_PER_MINUTE", float64(c.RateLimitPerMinute))
+	nonNegative("RATE_LIMIT_BURST", float64(c.RateLimitBurst))
+	nonNegative("ANALYZE_RATE_LIMIT_PER_MINUTE", float64(c.AnalyzeRateLimitPerMinute))
+	nonNegative("ANALYZE_RATE_LIMIT_BURST", float64(c.AnalyzeRateLimitBurst))
+	nonNegative("AUTH_FAILURE_RATE_LIMIT_PER_MINUTE", float64(c.AuthFailureRateLimitPerMinute))
+	nonNegative("AUTH_FAILURE_RATE_LIMIT_BURST", float64(c.AuthFailureRateLimitBurst))
+	for _, proxy := range c.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				fail("TRUSTED_PROXIES", "%q is not an IP address or CIDR range", proxy)
+			}
+		}
+	}
+
+	if c.GRPCMaxBatchSize < 1 {
+		fail("GRPC_MAX_BATCH_SIZE", "must be at least 1, got %d", c.GRPCMaxBatchSize)
+	}
+	if c.GRPCBatchConcurrency < 1 {
+		fail("GRPC_BATCH_CONCURRENCY", "must be at least 1, got %d", c.GRPCBatchConcurrency)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateOrigin accepts *, scheme://host[:port] and scheme://*.domain[:port]
+func validateOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	if err != nil {
+		return errors.New("is not a valid origin")
+	}
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return errors.New("must use http or https")
+	case u.Host == "" || strings.Contains(u.Host, "*"):
+		return errors.New("must have a host, with * only as the first label")
+	case strings.TrimSuffix(u.Path, "/") != "" || u.RawQuery != "" || u.User != nil:
+		return errors.New("must not have a path, query or credentials")
+	}
+	return nil
+}
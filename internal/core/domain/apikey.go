@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// API key roles
+const (
+	// RoleAdmin keys may manage other keys and read debug endpoints
+	RoleAdmin = "admin"
+	// RoleClient keys may call the analysis endpoints
+	RoleClient = "client"
+)
+
+// APIKey identifies a caller of the API. Only a hash of the key is stored.
+type APIKey struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Prefix is the start of the key, shown so keys can be told apart
+	Prefix string `json:"prefix"`
+	Hash   string `json:"-"`
+	Role   string `json:"role"`
+	// RateLimitPerMinute of 0 means the key is not rate limited
+	RateLimitPerMinute int `json:"rateLimitPerMinute"`
+	// DailyQuota of 0 means the key has no daily quota
+	DailyQuota int        `json:"dailyQuota"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Revoked reports whether the key may no longer be used
+func (k APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// CreateAPIKeyRequest is the body of the key creation endpoint. Limits that
+// are left out fall back to the configured defaults.
+type CreateAPIKeyRequest struct {
+	Name               string `json:"name" binding:"required,max=100"`
+	Role               string `json:"role,omitempty" binding:"omitempty,oneof=admin client"`
+	RateLimitPerMinute *int   `json:"rateLimitPerMinute,omitempty" binding:"omitempty,min=0"`
+	DailyQuota         *int   `json:"dailyQuota,omitempty" binding:"omitempty,min=0"`
+}
+
+// CreatedAPIKey is returned once when a key is created. The plaintext key
+// cannot be retrieved again.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
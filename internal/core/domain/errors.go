@@ -1,47 +1,350 @@
 package domain
 
+import (
+	"strings"
+	"time"
+)
+
+// Error codes are stable identifiers clients can switch on, independent of the
+// human-readable message
+const (
+	CodeInvalidURL          = "INVALID_URL"
+	CodeInvalidRequestBody  = "INVALID_REQUEST_BODY"
+	CodeValidationFailed    = "VALIDATION_FAILED"
+	CodeRequestTooLarge     = "REQUEST_TOO_LARGE"
+	CodeUnsupportedMedia    = "UNSUPPORTED_MEDIA_TYPE"
+	CodePageNotFound        = "PAGE_NOT_FOUND"
+	CodeDNSResolutionFailed = "DNS_RESOLUTION_FAILED"
+	CodeConnectionRefused   = "CONNECTION_REFUSED"
+	CodeConnectionReset     = "CONNECTION_RESET"
+	CodeTLSError            = "TLS_ERROR"
+	CodeRedirectLoop        = "REDIRECT_LOOP"
+	CodeTooManyRedirects    = "TOO_MANY_REDIRECTS"
+	CodePageTooLarge        = "PAGE_TOO_LARGE"
+	CodeUnsupportedContent  = "UNSUPPORTED_CONTENT_TYPE"
+	CodeUnsupportedEncoding = "UNSUPPORTED_CONTENT_ENCODING"
+	CodeUpstreamClientError = "UPSTREAM_CLIENT_ERROR"
+	CodeUpstreamServerError = "UPSTREAM_SERVER_ERROR"
+	CodePageNotAccessible   = "PAGE_NOT_ACCESSIBLE"
+	CodeCircuitOpen         = "CIRCUIT_OPEN"
+	CodeProxyError          = "PROXY_ERROR"
+	CodeProxyAuthRequired   = "PROXY_AUTH_REQUIRED"
+	CodeDestinationDenied   = "DESTINATION_NOT_ALLOWED"
+	CodeUnauthorized        = "UNAUTHORIZED"
+	CodeForbidden           = "FORBIDDEN"
+	CodeRateLimited         = "RATE_LIMITED"
+	CodeQuotaExceeded       = "QUOTA_EXCEEDED"
+	CodeAPIKeyNotFound      = "API_KEY_NOT_FOUND"
+	CodeTimeout             = "TIMEOUT"
+	CodeInternalError       = "INTERNAL_ERROR"
+)
+
 type APIError struct {
-	StatusCode  int    `json:"statusCode"`
-	Message     string `json:"message"`
-	Description string `json:"description,omitempty"`
+	StatusCode     int    `json:"statusCode"`
+	Code           string `json:"code"`
+	Message        string `json:"message"`
+	Description    string `json:"description,omitempty"`
+	UpstreamStatus int    `json:"upstreamStatus,omitempty"`
+	// RequestID identifies the request that failed, for matching reports to logs
+	RequestID string `json:"requestId,omitempty"`
+	// Fields is only rendered in problem+json responses so the legacy shape is unchanged
+	Fields []FieldError `json:"-"`
+	// RetryAfter is how long the target asked us to wait before trying again
+	RetryAfter time.Duration `json:"-"`
+	Err        error         `json:"-"`
+}
+
+// FieldError describes a validation failure for a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ProblemTypeBase prefixes the problem type URI of every error code
+const ProblemTypeBase = "https://webpage-analyzer/problems/"
+
+// Problem is an RFC 7807 problem details document
+type Problem struct {
+	Type           string       `json:"type"`
+	Title          string       `json:"title"`
+	Status         int          `json:"status"`
+	Detail         string       `json:"detail,omitempty"`
+	Instance       string       `json:"instance,omitempty"`
+	Code           string       `json:"code"`
+	UpstreamStatus int          `json:"upstreamStatus,omitempty"`
+	RequestID      string       `json:"requestId,omitempty"`
+	Errors         []FieldError `json:"errors,omitempty"`
 }
 
 func (e *APIError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
 	return e.Message
 }
 
+// Unwrap exposes the underlying cause, if any
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an APIError with the same code, so that copies
+// carrying a cause still match the sentinel they were created from
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// WithCause returns a copy of the error that wraps the underlying cause
+func (e *APIError) WithCause(err error) *APIError {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// WithRetryAfter returns a copy of the error telling the client when to try again
+func (e *APIError) WithRetryAfter(d time.Duration) *APIError {
+	c := *e
+	c.RetryAfter = d
+	return &c
+}
+
+// WithFields returns a copy of the error carrying field-level validation failures
+func (e *APIError) WithFields(fields []FieldError) *APIError {
+	c := *e
+	c.Fields = fields
+	return &c
+}
+
+// Problem converts the error into an RFC 7807 problem for the given request URI
+func (e *APIError) Problem(instance string) *Problem {
+	return &Problem{
+		Type:           ProblemTypeBase + strings.ReplaceAll(strings.ToLower(e.Code), "_", "-"),
+		Title:          e.Message,
+		Status:         e.StatusCode,
+		Detail:         e.Description,
+		Instance:       instance,
+		Code:           e.Code,
+		UpstreamStatus: e.UpstreamStatus,
+		RequestID:      e.RequestID,
+		Errors:         e.Fields,
+	}
+}
+
+// NewUpstreamError maps a non-successful status returned by the target website
+// to an APIError
+func NewUpstreamError(statusCode int, status string) *APIError {
+	base := ErrUpstreamServerError
+	if statusCode >= 400 && statusCode < 500 {
+		base = ErrUpstreamClientError
+	}
+	err := *base
+	err.UpstreamStatus = statusCode
+	err.Description = "The website responded with " + status
+	return &err
+}
+
 var (
-    ErrInvalidURL = &APIError{
-        StatusCode:  400,
-        Message:     "Invalid URL Format",
-        Description: "The URL provided is invalid or malformed. Please ensure it starts with http:// or https://.",
-    }
-
-    ErrPageNotFound = &APIError{
-        StatusCode:  404,
-        Message:     "Resource Not Found",
-        Description: "The requested resource could not be found. Please check the URL.",
-    }
-
-    ErrDNSResolutionFailed = &APIError{
-        StatusCode:  502,
-        Message:     "DNS Resolution Failed",
-        Description: "The domain could not be resolved. Please check if the URL is correct.",
-    }
+	ErrInvalidURL = &APIError{
+		StatusCode:  400,
+		Code:        CodeInvalidURL,
+		Message:     "Invalid URL Format",
+		Description: "The URL provided is invalid or malformed. Please ensure it starts with http:// or https://.",
+	}
+
+	ErrInvalidRequestBody = &APIError{
+		StatusCode:  400,
+		Code:        CodeInvalidRequestBody,
+		Message:     "Invalid Request Body",
+		Description: "The request body could not be parsed. Please send a valid JSON document.",
+	}
+
+	ErrValidationFailed = &APIError{
+		StatusCode:  400,
+		Code:        CodeValidationFailed,
+		Message:     "Validation Failed",
+		Description: "One or more request fields are invalid.",
+	}
+
+	ErrRequestTooLarge = &APIError{
+		StatusCode:  413,
+		Code:        CodeRequestTooLarge,
+		Message:     "Request Too Large",
+		Description: "The submitted document exceeds the maximum allowed size.",
+	}
+
+	ErrUnsupportedMediaType = &APIError{
+		StatusCode:  415,
+		Code:        CodeUnsupportedMedia,
+		Message:     "Unsupported Media Type",
+		Description: "Send HTML as application/json, text/html or multipart/form-data.",
+	}
+
+	ErrPageNotFound = &APIError{
+		StatusCode:  404,
+		Code:        CodePageNotFound,
+		Message:     "Resource Not Found",
+		Description: "The requested resource could not be found. Please check the URL.",
+	}
+
+	ErrDNSResolutionFailed = &APIError{
+		StatusCode:  502,
+		Code:        CodeDNSResolutionFailed,
+		Message:     "DNS Resolution Failed",
+		Description: "The domain could not be resolved. Please check if the URL is correct.",
+	}
+
+	ErrConnectionRefused = &APIError{
+		StatusCode:  502,
+		Code:        CodeConnectionRefused,
+		Message:     "Connection Refused",
+		Description: "The target server refused the connection.",
+	}
+
+	ErrConnectionReset = &APIError{
+		StatusCode:  502,
+		Code:        CodeConnectionReset,
+		Message:     "Connection Reset",
+		Description: "The connection was closed by the target server before a response was received.",
+	}
+
+	ErrTLSHandshakeFailed = &APIError{
+		StatusCode:  502,
+		Code:        CodeTLSError,
+		Message:     "TLS Error",
+		Description: "A secure connection could not be established. The certificate may be invalid or expired.",
+	}
+
+	ErrRedirectLoop = &APIError{
+		StatusCode:  502,
+		Code:        CodeRedirectLoop,
+		Message:     "Redirect Loop Detected",
+		Description: "The website redirected back to a URL that was already visited.",
+	}
+
+	ErrTooManyRedirects = &APIError{
+		StatusCode:  502,
+		Code:        CodeTooManyRedirects,
+		Message:     "Too Many Redirects",
+		Description: "The website redirected more times than the allowed maximum.",
+	}
+
+	ErrPageTooLarge = &APIError{
+		StatusCode:  502,
+		Code:        CodePageTooLarge,
+		Message:     "Page Too Large",
+		Description: "The page exceeds the maximum size that can be analyzed.",
+	}
+
+	ErrUnsupportedContentType = &APIError{
+		StatusCode:  422,
+		Code:        CodeUnsupportedContent,
+		Message:     "Unsupported Content Type",
+		Description: "The URL did not return an HTML page.",
+	}
+
+	ErrUnsupportedContentEncoding = &APIError{
+		StatusCode:  422,
+		Code:        CodeUnsupportedEncoding,
+		Message:     "Unsupported Content Encoding",
+		Description: "The URL returned content in an encoding that cannot be decoded.",
+	}
+
+	ErrUpstreamClientError = &APIError{
+		StatusCode:  502,
+		Code:        CodeUpstreamClientError,
+		Message:     "Upstream Client Error",
+		Description: "The website rejected the request.",
+	}
+
+	ErrUpstreamServerError = &APIError{
+		StatusCode:  502,
+		Code:        CodeUpstreamServerError,
+		Message:     "Upstream Server Error",
+		Description: "The website failed to process the request.",
+	}
+
 	ErrPageNotAccessible = &APIError{
 		StatusCode:  503,
+		Code:        CodePageNotAccessible,
 		Message:     "Service Unavailable",
 		Description: "The target server is not responding or is temporarily unavailable",
 	}
-	
-    ErrTimeout = &APIError{
-        StatusCode:  504,
-        Message:     "Request Timeout",
-        Description: "The website took too long to respond. Please try again later.",
-    }
 
-    ErrInternalServer = &APIError{
+	ErrCircuitOpen = &APIError{
+		StatusCode:  503,
+		Code:        CodeCircuitOpen,
+		Message:     "Host Temporarily Unavailable",
+		Description: "Recent requests to this host kept failing, so it is not being contacted for a while. Please try again later.",
+	}
+
+	ErrProxyFailed = &APIError{
+		StatusCode:  502,
+		Code:        CodeProxyError,
+		Message:     "Proxy Error",
+		Description: "The outbound proxy could not be reached or refused to forward the request.",
+	}
+
+	ErrProxyAuthRequired = &APIError{
+		StatusCode:  502,
+		Code:        CodeProxyAuthRequired,
+		Message:     "Proxy Authentication Failed",
+		Description: "The outbound proxy rejected the configured credentials.",
+	}
+
+	ErrDestinationNotAllowed = &APIError{
+		StatusCode:  403,
+		Code:        CodeDestinationDenied,
+		Message:     "Destination Not Allowed",
+		Description: "The host is not on the list of sites this service may contact.",
+	}
+
+	ErrUnauthorized = &APIError{
+		StatusCode:  401,
+		Code:        CodeUnauthorized,
+		Message:     "Unauthorized",
+		Description: "A valid API key is required. Send it in the X-API-Key header or as a bearer token.",
+	}
+
+	ErrForbidden = &APIError{
+		StatusCode:  403,
+		Code:        CodeForbidden,
+		Message:     "Forbidden",
+		Description: "The API key is not allowed to perform this operation.",
+	}
+
+	ErrRateLimited = &APIError{
+		StatusCode:  429,
+		Code:        CodeRateLimited,
+		Message:     "Too Many Requests",
+		Description: "The request rate limit has been exceeded. Please retry after the time given in the Retry-After header.",
+	}
+
+	ErrQuotaExceeded = &APIError{
+		StatusCode:  429,
+		Code:        CodeQuotaExceeded,
+		Message:     "Daily Quota Exceeded",
+		Description: "The API key has used its daily request quota. The quota resets at midnight UTC.",
+	}
+
+	ErrAPIKeyNotFound = &APIError{
+		StatusCode:  404,
+		Code:        CodeAPIKeyNotFound,
+		Message:     "API Key Not Found",
+		Description: "No API key exists with the given ID.",
+	}
+
+	ErrTimeout = &APIError{
+		StatusCode:  504,
+		Code:        CodeTimeout,
+		Message:     "Request Timeout",
+		Description: "The website took too long to respond. Please try again later.",
+	}
+
+	ErrInternalServer = &APIError{
 		StatusCode:  500,
+		Code:        CodeInternalError,
 		Message:     "Internal Server Error",
 		Description: "An unexpected error occurred. Please try again later.",
 	}
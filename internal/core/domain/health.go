@@ -0,0 +1,23 @@
+package domain
+
+// Health statuses of checks and reports
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+// HealthReport is the outcome of a liveness or readiness probe. Status is down
+// when any check is down.
+type HealthReport struct {
+	Status string              `json:"status"`
+	Checks []HealthCheckResult `json:"checks"`
+}
+
+// HealthCheckResult is the outcome of one health check
+type HealthCheckResult struct {
+	Name      string         `json:"name"`
+	Status    string         `json:"status"`
+	LatencyMs float64        `json:"latencyMs"`
+	Error     string         `json:"error,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
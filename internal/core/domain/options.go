@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// redactedValue replaces sensitive values in logged fetch options
+const redactedValue = "[REDACTED]"
+
+// ProxyDirect as a per-request proxy bypasses the configured proxy
+const ProxyDirect = "direct"
+
+// reservedHeaders are managed by the HTTP client and cannot be overridden
+var reservedHeaders = map[string]bool{
+	"host":              true,
+	"connection":        true,
+	"content-length":    true,
+	"transfer-encoding": true,
+	"accept-encoding":   true,
+	"te":                true,
+	"upgrade":           true,
+}
+
+// IsSensitiveHeader reports whether a header carries credentials that must not
+// be logged or forwarded to another host
+func IsSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "authorization", "proxy-authorization", "cookie":
+		return true
+	}
+	return strings.Contains(name, "token") ||
+		strings.Contains(name, "secret") ||
+		strings.Contains(name, "api-key") ||
+		strings.Contains(name, "apikey")
+}
+
+// Validate checks the parts of the options that cannot be expressed with
+// binding tags and returns one FieldError per problem found
+func (o FetchOptions) Validate() []FieldError {
+	var fields []FieldError
+	for name, value := range o.Headers {
+		switch {
+		case !isToken(name):
+			fields = append(fields, FieldError{Field: "headers." + name, Rule: "header", Message: "is not a valid header name"})
+		case reservedHeaders[strings.ToLower(name)]:
+			fields = append(fields, FieldError{Field: "headers." + name, Rule: "header", Message: "is managed by the analyzer and cannot be set"})
+		case strings.ContainsAny(value, "\r\n"):
+			fields = append(fields, FieldError{Field: "headers." + name, Rule: "header", Message: "must not contain line breaks"})
+		}
+	}
+	for name, value := range o.Cookies {
+		if !isToken(name) || strings.ContainsAny(value, "\r\n;") {
+			fields = append(fields, FieldError{Field: "cookies." + name, Rule: "cookie", Message: "is not a valid cookie"})
+		}
+	}
+	if strings.ContainsAny(o.UserAgent, "\r\n") {
+		fields = append(fields, FieldError{Field: "userAgent", Rule: "header", Message: "must not contain line breaks"})
+	}
+	if strings.ContainsAny(o.AcceptLanguage, "\r\n") {
+		fields = append(fields, FieldError{Field: "acceptLanguage", Rule: "header", Message: "must not contain line breaks"})
+	}
+	if o.Proxy != "" && o.Proxy != ProxyDirect {
+		if _, err := ParseProxyURL(o.Proxy); err != nil {
+			fields = append(fields, FieldError{Field: "proxy", Rule: "proxy", Message: err.Error()})
+		}
+	}
+	return fields
+}
+
+// Redacted returns a copy of the options that is safe to log
+func (o FetchOptions) Redacted() FetchOptions {
+	r := o
+	if o.Headers != nil {
+		r.Headers = make(map[string]string, len(o.Headers))
+		for name, value := range o.Headers {
+			if IsSensitiveHeader(name) {
+				value = redactedValue
+			}
+			r.Headers[name] = value
+		}
+	}
+	if o.Cookies != nil {
+		r.Cookies = make(map[string]string, len(o.Cookies))
+		for name := range o.Cookies {
+			r.Cookies[name] = redactedValue
+		}
+	}
+	if o.Auth != nil {
+		auth := *o.Auth
+		if auth.Password != "" {
+			auth.Password = redactedValue
+		}
+		if auth.Token != "" {
+			auth.Token = redactedValue
+		}
+		r.Auth = &auth
+	}
+	if u, err := url.Parse(o.Proxy); err == nil && u.User != nil {
+		r.Proxy = u.Redacted()
+	}
+	return r
+}
+
+// ParseProxyURL parses the URL of an HTTP, HTTPS or SOCKS5 proxy
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, errors.New("must be a valid URL")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, errors.New("must use the http, https, socks5 or socks5h scheme")
+	}
+	if u.Host == "" {
+		return nil, errors.New("must include a host")
+	}
+	return u, nil
+}
+
+// isToken reports whether s is a valid RFC 7230 token
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r > 0x7e || r <= 0x20 || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return false
+		}
+	}
+	return true
+}
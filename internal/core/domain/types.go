@@ -1,12 +1,22 @@
 package domain
 
+import "time"
+
 // PageAnalysis represents the result of webpage analysis
 type PageAnalysis struct {
-	HTMLVersion  string       `json:"htmlVersion"`
-	PageTitle    string       `json:"pageTitle"`
-	Headings     HeadingCount `json:"headings"`
-	Links        LinkAnalysis `json:"links"`
-	HasLoginForm bool         `json:"hasLoginForm"`
+	HTMLVersion  string             `json:"htmlVersion"`
+	PageTitle    string             `json:"pageTitle"`
+	Headings     HeadingCount       `json:"headings"`
+	Links        LinkAnalysis       `json:"links"`
+	HasLoginForm bool               `json:"hasLoginForm"`
+	Redirects    RedirectAnalysis   `json:"redirects"`
+	Performance  PerformanceMetrics `json:"performance"`
+	Content      ContentInfo        `json:"content"`
+	Caching      CachingAnalysis    `json:"caching"`
+	// Truncated is set when only the beginning of an oversized page was analyzed
+	Truncated bool `json:"truncated"`
+	// Cache reports how the response cache was used, when caching is enabled
+	Cache *CacheInfo `json:"cache,omitempty"`
 }
 
 // HeadingCount stores the count of different heading levels
@@ -24,9 +34,232 @@ type LinkAnalysis struct {
 	Internal     int `json:"internal"`
 	External     int `json:"external"`
 	Inaccessible int `json:"inaccessible"`
+	// Checks holds the result of probing each distinct link, in page order
+	Checks []LinkCheckResult `json:"checks,omitempty"`
+	// Unchecked counts the distinct links beyond the per-page link check limit
+	Unchecked int `json:"unchecked,omitempty"`
+}
+
+// RedirectHop describes a single redirect response received while fetching a page
+type RedirectHop struct {
+	URL        string  `json:"url"`
+	StatusCode int     `json:"statusCode"`
+	Location   string  `json:"location"`
+	LatencyMs  float64 `json:"latencyMs"`
+}
+
+// RedirectAnalysis describes the redirect chain followed to reach the analyzed page
+type RedirectAnalysis struct {
+	Chain          []RedirectHop `json:"chain"`
+	FinalURL       string        `json:"finalUrl"`
+	HTTPSUpgrade   bool          `json:"httpsUpgrade"`
+	HTTPSDowngrade bool          `json:"httpsDowngrade"`
+}
+
+// PerformanceMetrics breaks down where time was spent fetching the analyzed page.
+// Connection timings refer to the request that returned the page; TotalMs also
+// includes any redirects followed before it. Pages served from the cache
+// without a request have no timings.
+type PerformanceMetrics struct {
+	DNSLookupMs       float64 `json:"dnsLookupMs"`
+	TCPConnectMs      float64 `json:"tcpConnectMs"`
+	TLSHandshakeMs    float64 `json:"tlsHandshakeMs"`
+	TimeToFirstByteMs float64 `json:"timeToFirstByteMs"`
+	ContentDownloadMs float64 `json:"contentDownloadMs"`
+	TotalMs           float64 `json:"totalMs"`
+	CompressedSize    int64   `json:"compressedSize"`
+	UncompressedSize  int64   `json:"uncompressedSize"`
+	// ContentEncoding is the compression the page was served with, or
+	// EncodingIdentity
+	ContentEncoding string `json:"contentEncoding"`
+	// CompressionRatio is the uncompressed size divided by the compressed size
+	CompressionRatio float64   `json:"compressionRatio"`
+	ConnectionReused bool      `json:"connectionReused"`
+	Findings         []Finding `json:"findings,omitempty"`
+}
+
+// EncodingIdentity is the ContentEncoding of a page served without compression
+const EncodingIdentity = "identity"
+
+// Performance finding codes
+const (
+	FindingUncompressedText = "UNCOMPRESSED_TEXT"
+)
+
+// Finding is a potential problem noticed while analyzing a page
+type Finding struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// Cacheability values describe how caches may store a page
+const (
+	// CacheabilityPublic pages may be stored and reused by shared caches such as CDNs
+	CacheabilityPublic = "public"
+	// CacheabilityRevalidate pages may be stored but must be revalidated before reuse
+	CacheabilityRevalidate = "revalidate"
+	// CacheabilityPrivate pages may only be cached by the browser
+	CacheabilityPrivate = "private"
+	// CacheabilityNoStore pages must not be cached at all
+	CacheabilityNoStore = "no-store"
+	// CacheabilityUncacheable pages vary on every request (Vary: *)
+	CacheabilityUncacheable = "uncacheable"
+	// CacheabilityUnspecified pages have no caching headers, leaving caches to guess
+	CacheabilityUnspecified = "unspecified"
+)
+
+// CachingAnalysis interprets the caching and CDN headers of the page response
+type CachingAnalysis struct {
+	Cacheability string `json:"cacheability"`
+	// Cacheable is set when shared caches may reuse the page without revalidation
+	Cacheable     bool     `json:"cacheable"`
+	MaxAgeSeconds int64    `json:"maxAgeSeconds"`
+	AgeSeconds    int64    `json:"ageSeconds"`
+	CacheControl  string   `json:"cacheControl,omitempty"`
+	Expires       string   `json:"expires,omitempty"`
+	ETag          string   `json:"etag,omitempty"`
+	LastModified  string   `json:"lastModified,omitempty"`
+	Vary          []string `json:"vary,omitempty"`
+	Via           string   `json:"via,omitempty"`
+	// CDN is the provider detected from response headers
+	CDN string `json:"cdn,omitempty"`
+	// CacheStatus is the edge cache result, such as HIT, MISS or EXPIRED
+	CacheStatus  string               `json:"cacheStatus,omitempty"`
+	ServerTiming []ServerTimingMetric `json:"serverTiming,omitempty"`
+}
+
+// ServerTimingMetric is one entry of the Server-Timing header
+type ServerTimingMetric struct {
+	Name        string  `json:"name"`
+	DurationMs  float64 `json:"durationMs,omitempty"`
+	Description string  `json:"description,omitempty"`
+}
+
+// ContentInfo describes the media type and character encoding of the fetched page
+type ContentInfo struct {
+	ContentType string `json:"contentType"`
+	Charset     string `json:"charset"`
+	// CharsetSource is where the charset came from: header, bom, meta or default
+	CharsetSource string `json:"charsetSource"`
+}
+
+// FetchOptions holds per-request settings for fetching the target page
+type FetchOptions struct {
+	MaxRedirects   *int              `json:"maxRedirects,omitempty" binding:"omitempty,min=0,max=20"`
+	Headers        map[string]string `json:"headers,omitempty" binding:"omitempty,max=50"`
+	Cookies        map[string]string `json:"cookies,omitempty" binding:"omitempty,max=50"`
+	Auth           *FetchAuth        `json:"auth,omitempty"`
+	UserAgent      string            `json:"userAgent,omitempty" binding:"omitempty,max=512"`
+	AcceptLanguage string            `json:"acceptLanguage,omitempty" binding:"omitempty,max=256"`
+	TimeoutMs      int               `json:"timeoutMs,omitempty" binding:"omitempty,min=1,max=120000"`
+	// MaxBodyBytes lowers the configured page size limit for this request
+	MaxBodyBytes int64 `json:"maxBodyBytes,omitempty" binding:"omitempty,min=1"`
+	// AllowTruncated analyzes the first MaxBodyBytes of an oversized page
+	// instead of rejecting it
+	AllowTruncated bool `json:"allowTruncated,omitempty"`
+	// Proxy overrides the configured outbound proxy for this request. It takes
+	// an http, https or socks5 URL of an allowed proxy, or "direct" when no
+	// proxy is configured.
+	Proxy string `json:"proxy,omitempty" binding:"omitempty,max=2048"`
+}
+
+// FetchAuth holds the credentials sent to the target website
+type FetchAuth struct {
+	Type     string `json:"type" binding:"required,oneof=basic bearer"`
+	Username string `json:"username,omitempty" binding:"required_if=Type basic"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty" binding:"required_if=Type bearer"`
+}
+
+// FetchResult represents a fetched page along with the details of how it was reached
+type FetchResult struct {
+	Body        string
+	StatusCode  int
+	Redirects   RedirectAnalysis
+	Performance PerformanceMetrics
+	Content     ContentInfo
+	Caching     CachingAnalysis
+	Truncated   bool
+	Cache       *CacheInfo
+}
+
+// Cache statuses reported for fetched pages
+const (
+	// CacheMiss means the page was downloaded in full
+	CacheMiss = "miss"
+	// CacheHit means a fresh cached copy was used without contacting the site
+	CacheHit = "hit"
+	// CacheRevalidated means the site confirmed the cached copy is unchanged
+	CacheRevalidated = "revalidated"
+	// CacheBypass means the request options made the page ineligible for caching
+	CacheBypass = "bypass"
+)
+
+// CacheInfo describes how the response cache was used for a page. On a hit
+// the performance metrics are those of the fetch that filled the cache.
+type CacheInfo struct {
+	Status       string `json:"status"`
+	Stored       bool   `json:"stored,omitempty"`
+	AgeSeconds   int64  `json:"ageSeconds,omitempty"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
 }
 
 // AnalysisRequest represents the incoming request for webpage analysis
 type AnalysisRequest struct {
 	URL string `json:"url" binding:"required,url"`
+	FetchOptions
+}
+
+// HTMLAnalysisRequest represents a request to analyze HTML supplied by the caller
+// instead of fetching it. BaseURL is used to resolve relative links.
+type HTMLAnalysisRequest struct {
+	HTML    string `json:"html" binding:"required"`
+	BaseURL string `json:"baseUrl,omitempty" binding:"omitempty,url"`
+}
+
+// CircuitBreakerState describes the circuit breaker guarding outbound requests to one host
+type CircuitBreakerState struct {
+	Host                string     `json:"host"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	LastFailure         string     `json:"lastFailure,omitempty"`
+}
+
+// HostLimitStats describes the politeness limiter applied to outbound requests to one host.
+// Waiting counts the requests waiting for the host right now and QueuedRequests
+// all requests that have had to wait so far.
+type HostLimitStats struct {
+	Host              string  `json:"host"`
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	CrawlDelayMs      int64   `json:"crawlDelayMs,omitempty"`
+	InFlight          int     `json:"inFlight"`
+	Waiting           int     `json:"waiting"`
+	QueuedRequests    int64   `json:"queuedRequests"`
+	TotalWaitMs       float64 `json:"totalWaitMs"`
+	MaxWaitMs         float64 `json:"maxWaitMs"`
+}
+
+// Link check outcomes
+const (
+	LinkReachable  = "reachable"
+	LinkRestricted = "restricted"
+	LinkBroken     = "broken"
+)
+
+// LinkCheckResult describes whether a link could be reached and how that was determined
+type LinkCheckResult struct {
+	URL        string `json:"url"`
+	Status     string `json:"status"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Method     string `json:"method,omitempty"`
+	FinalURL   string `json:"finalUrl,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Reachable reports whether the link resolved to a successful response
+func (r LinkCheckResult) Reachable() bool {
+	return r.Status == LinkReachable
 }
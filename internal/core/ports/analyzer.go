@@ -7,20 +7,39 @@ import (
 
 // PageAnalyzer defines the interface for webpage analysis
 type PageAnalyzer interface {
-	Analyze(ctx context.Context, url string) (*domain.PageAnalysis, error)
+	Analyze(ctx context.Context, req domain.AnalysisRequest) (*domain.PageAnalysis, error)
+	AnalyzeHTML(ctx context.Context, req domain.HTMLAnalysisRequest) (*domain.PageAnalysis, error)
 }
 
 // HTMLParser defines the interface for HTML parsing operations
 type HTMLParser interface {
-	GetHTMLVersion(doc string) string
-	GetTitle(doc string) string
-	CountHeadings(doc string) domain.HeadingCount
-	AnalyzeLinks(doc string, baseURL string) domain.LinkAnalysis
-	HasLoginForm(doc string) bool
+	GetHTMLVersion(ctx context.Context, doc string) string
+	GetTitle(ctx context.Context, doc string) string
+	CountHeadings(ctx context.Context, doc string) domain.HeadingCount
+	AnalyzeLinks(ctx context.Context, doc string, baseURL string) domain.LinkAnalysis
+	// ExtractLinks returns the distinct absolute http and https links of the
+	// document in the order they appear
+	ExtractLinks(ctx context.Context, doc string, baseURL string) []string
+	HasLoginForm(ctx context.Context, doc string) bool
 }
 
 // HTTPClient defines the interface for making HTTP requests
 type HTTPClient interface {
-	FetchPage(ctx context.Context, url string) (string, error)
-	CheckLink(ctx context.Context, url string) bool
+	FetchPage(ctx context.Context, url string, opts domain.FetchOptions) (*domain.FetchResult, error)
+	CheckLink(ctx context.Context, url string) domain.LinkCheckResult
+}
+
+// CircuitBreakerReporter exposes the state of per-host circuit breakers
+type CircuitBreakerReporter interface {
+	CircuitBreakers() []domain.CircuitBreakerState
+}
+
+// HostLimitReporter exposes the state of per-host politeness limiters
+type HostLimitReporter interface {
+	HostLimits() []domain.HostLimitStats
+}
+
+// ConfigReporter exposes the settings the service runs with, secrets masked
+type ConfigReporter interface {
+	EffectiveConfig() map[string]any
 }
@@ -0,0 +1,49 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// APIKeyStore persists API keys and their daily usage
+type APIKeyStore interface {
+	Create(ctx context.Context, key domain.APIKey) error
+	// Get returns domain.ErrAPIKeyNotFound when no key has the ID
+	Get(ctx context.Context, id string) (*domain.APIKey, error)
+	// FindByHash returns domain.ErrAPIKeyNotFound when no key has the hash
+	FindByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, id string, at time.Time) error
+	// IncrementUsage counts a request against the key on the given UTC day
+	// and returns the number of requests made that day
+	IncrementUsage(ctx context.Context, id string, day string) (int64, error)
+	// DecrementUsage takes back a request counted on the given UTC day; it
+	// does nothing once that day is over or nothing was counted
+	DecrementUsage(ctx context.Context, id string, day string) error
+	// Usage returns the number of requests made with the key on the given UTC day
+	Usage(ctx context.Context, id string, day string) (int64, error)
+	// Ping reports whether the store can currently serve requests
+	Ping(ctx context.Context) error
+}
+
+// APIKeyManager creates API keys and authenticates the requests made with them
+type APIKeyManager interface {
+	Create(ctx context.Context, req domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error)
+	// Register stores a key whose plaintext is already known, such as the
+	// bootstrap admin key from the configuration
+	Register(ctx context.Context, name, rawKey, role string) (*domain.APIKey, error)
+	List(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, id string) error
+	// Authenticate resolves a plaintext key, failing with ErrUnauthorized when
+	// it is unknown or revoked
+	Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error)
+	// Allow applies the key's rate limit and reserves a request from its
+	// daily quota before the request is served, failing with ErrRateLimited
+	// or ErrQuotaExceeded
+	Allow(ctx context.Context, key domain.APIKey) error
+	// Refund gives back the quota reserved by Allow for a request that was
+	// rejected instead of served
+	Refund(ctx context.Context, key domain.APIKey) error
+}
@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// HealthCheck verifies one thing the service needs in order to serve traffic.
+// Details are reported whether or not the check fails.
+type HealthCheck interface {
+	Name() string
+	Check(ctx context.Context) (details map[string]any, err error)
+}
+
+// HealthReporter runs the registered liveness and readiness checks
+type HealthReporter interface {
+	Live(ctx context.Context) domain.HealthReport
+	Ready(ctx context.Context) domain.HealthReport
+}
@@ -2,64 +2,233 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
+	"sync"
 
 	"github.com/suraif16/webpage-analyzer/internal/core/domain"
 	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+var tracer = otel.Tracer("github.com/suraif16/webpage-analyzer/internal/core/services")
+
+// uncompressedFindingSize is the size above which a page served without
+// compression is reported as a performance finding
+const uncompressedFindingSize = 10 << 10
+
+// LinkCheckPolicy bounds the link checks made for each fetched page
+type LinkCheckPolicy struct {
+	// MaxLinks is the largest number of distinct links checked per page; 0 disables link checks
+	MaxLinks int
+	// Concurrency is the number of links of one page checked at once
+	Concurrency int
+}
+
 type analyzerService struct {
 	httpClient ports.HTTPClient
 	htmlParser ports.HTMLParser
-	logger   *zap.Logger
+	linkChecks LinkCheckPolicy
+	logger     *zap.Logger
 }
 
-
-func NewAnalyzerService(httpClient ports.HTTPClient, htmlParser ports.HTMLParser, logger *zap.Logger) ports.PageAnalyzer {
+func NewAnalyzerService(httpClient ports.HTTPClient, htmlParser ports.HTMLParser, linkChecks LinkCheckPolicy, logger *zap.Logger) ports.PageAnalyzer {
+	if linkChecks.Concurrency < 1 {
+		linkChecks.Concurrency = 1
+	}
 	return &analyzerService{
 		httpClient: httpClient,
 		htmlParser: htmlParser,
+		linkChecks: linkChecks,
 		logger:     logger,
 	}
 }
 
-func (s *analyzerService) Analyze(ctx context.Context, urlStr string) (*domain.PageAnalysis, error) {
-	
+func (s *analyzerService) Analyze(ctx context.Context, req domain.AnalysisRequest) (analysis *domain.PageAnalysis, err error) {
+	urlStr := req.URL
+
+	ctx, span := tracer.Start(ctx, "Analyze")
+	defer func() { endSpan(span, err) }()
+
 	// Validate URL
-    if _, err := url.ParseRequestURI(urlStr); err != nil {
-        s.logger.Error("invalid URL",
-            zap.String("url", urlStr),
-            zap.Error(err))
-        return nil, domain.ErrInvalidURL
-    }
+	if u, err := url.Parse(urlStr); err == nil {
+		span.SetAttributes(attribute.String("url.full", u.Redacted()))
+	}
+	if _, err := url.ParseRequestURI(urlStr); err != nil {
+		s.log(ctx).Error("invalid URL",
+			zap.String("url", urlStr),
+			zap.Error(err))
+		return nil, domain.ErrInvalidURL
+	}
+
+	if fields := req.FetchOptions.Validate(); len(fields) > 0 {
+		return nil, domain.ErrValidationFailed.WithFields(fields)
+	}
 
 	// Fetch page content
-    content, err := s.httpClient.FetchPage(ctx, urlStr)
-    if err != nil {
-        s.logger.Error("failed to fetch page",
-            zap.String("url", urlStr),
-            zap.Error(err))
-        switch {
-        case err == context.DeadlineExceeded:
-            return nil, domain.ErrTimeout
-        default:
-            return nil, domain.ErrPageNotAccessible
-        }
-    }
+	page, err := s.httpClient.FetchPage(ctx, urlStr, req.FetchOptions)
+	if err != nil {
+		s.log(ctx).Error("failed to fetch page",
+			zap.String("url", urlStr),
+			zap.Error(err))
+		var apiErr *domain.APIError
+		switch {
+		case errors.As(err, &apiErr):
+			return nil, apiErr
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, domain.ErrTimeout.WithCause(err)
+		default:
+			return nil, domain.ErrPageNotAccessible.WithCause(err)
+		}
+	}
+
+	// Links are resolved against the page that was actually served
+	baseURL := page.Redirects.FinalURL
+	if baseURL == "" {
+		baseURL = urlStr
+	}
 
 	// Analyze page
-	s.logger.Info("parsing webpage content")
-	analysis := &domain.PageAnalysis{
-		HTMLVersion:  s.htmlParser.GetHTMLVersion(content),
-		PageTitle:    s.htmlParser.GetTitle(content),
-		Headings:     s.htmlParser.CountHeadings(content),
-		Links:        s.htmlParser.AnalyzeLinks(content, urlStr),
-		HasLoginForm: s.htmlParser.HasLoginForm(content),
-	}
-
-    s.logger.Info("page analysis completed",
-        zap.String("url", urlStr))
-		
+	analysis = s.parse(ctx, page.Body, baseURL)
+	s.checkLinks(ctx, page.Body, baseURL, &analysis.Links)
+	analysis.Redirects = page.Redirects
+	analysis.Performance = page.Performance
+	analysis.Performance.Findings = performanceFindings(page.Performance)
+	analysis.Content = page.Content
+	analysis.Caching = page.Caching
+	analysis.Truncated = page.Truncated
+	analysis.Cache = page.Cache
+
+	s.log(ctx).Info("page analysis completed",
+		zap.String("url", urlStr),
+		zap.Int("redirects", len(page.Redirects.Chain)),
+		zap.Int("links_checked", len(analysis.Links.Checks)))
+
+	return analysis, nil
+}
+
+// AnalyzeHTML runs the parsing pipeline over HTML supplied by the caller
+// without fetching anything
+func (s *analyzerService) AnalyzeHTML(ctx context.Context, req domain.HTMLAnalysisRequest) (analysis *domain.PageAnalysis, err error) {
+	ctx, span := tracer.Start(ctx, "AnalyzeHTML", trace.WithAttributes(attribute.Int("webanalyzer.html_size", len(req.HTML))))
+	defer func() { endSpan(span, err) }()
+
+	if req.BaseURL != "" {
+		if _, err := url.ParseRequestURI(req.BaseURL); err != nil {
+			s.log(ctx).Error("invalid base URL",
+				zap.String("base_url", req.BaseURL),
+				zap.Error(err))
+			return nil, domain.ErrInvalidURL
+		}
+	}
+
+	analysis = s.parse(ctx, req.HTML, req.BaseURL)
+	analysis.Redirects = domain.RedirectAnalysis{Chain: []domain.RedirectHop{}, FinalURL: req.BaseURL}
+
+	s.log(ctx).Info("html analysis completed",
+		zap.String("base_url", req.BaseURL),
+		zap.Int("size", len(req.HTML)))
+
 	return analysis, nil
 }
+
+// parse runs every HTML parser check over content, each in its own span
+func (s *analyzerService) parse(ctx context.Context, content, baseURL string) *domain.PageAnalysis {
+	s.log(ctx).Info("parsing webpage content")
+	return &domain.PageAnalysis{
+		HTMLVersion: traced(ctx, "GetHTMLVersion", s.htmlParser.GetHTMLVersion, content),
+		PageTitle:   traced(ctx, "GetTitle", s.htmlParser.GetTitle, content),
+		Headings:    traced(ctx, "CountHeadings", s.htmlParser.CountHeadings, content),
+		Links: traced(ctx, "AnalyzeLinks", func(ctx context.Context, doc string) domain.LinkAnalysis {
+			return s.htmlParser.AnalyzeLinks(ctx, doc, baseURL)
+		}, content),
+		HasLoginForm: traced(ctx, "HasLoginForm", s.htmlParser.HasLoginForm, content),
+	}
+}
+
+// checkLinks probes the distinct links of a fetched page, up to the policy
+// limit and a bounded number at a time, and counts the broken ones as
+// inaccessible. Restricted links exist, so they are not counted.
+func (s *analyzerService) checkLinks(ctx context.Context, content, baseURL string, links *domain.LinkAnalysis) {
+	if s.linkChecks.MaxLinks <= 0 {
+		return
+	}
+
+	urls := traced(ctx, "ExtractLinks", func(ctx context.Context, doc string) []string {
+		return s.htmlParser.ExtractLinks(ctx, doc, baseURL)
+	}, content)
+	if len(urls) > s.linkChecks.MaxLinks {
+		links.Unchecked = len(urls) - s.linkChecks.MaxLinks
+		urls = urls[:s.linkChecks.MaxLinks]
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, s.linkChecks.Concurrency)
+	)
+	links.Checks = make([]domain.LinkCheckResult, len(urls))
+	for i, u := range urls {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			links.Checks[i] = s.httpClient.CheckLink(ctx, u)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range links.Checks {
+		if result.Status == domain.LinkBroken {
+			links.Inaccessible++
+		}
+	}
+}
+
+// traced runs a parser method inside a span named after it
+func traced[T any](ctx context.Context, name string, fn func(context.Context, string) T, doc string) T {
+	ctx, span := tracer.Start(ctx, "parser."+name)
+	defer span.End()
+	return fn(ctx, doc)
+}
+
+// log returns the request-scoped logger carried by ctx
+func (s *analyzerService) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+// endSpan records the outcome of an analysis on its span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		var apiErr *domain.APIError
+		if errors.As(err, &apiErr) {
+			span.SetAttributes(attribute.String("error.type", apiErr.Code))
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// performanceFindings flags avoidable costs in how the page was delivered
+func performanceFindings(p domain.PerformanceMetrics) []domain.Finding {
+	var findings []domain.Finding
+	if p.ContentEncoding == domain.EncodingIdentity && p.UncompressedSize >= uncompressedFindingSize {
+		findings = append(findings, domain.Finding{
+			Code:     domain.FindingUncompressedText,
+			Severity: "warning",
+			Message: fmt.Sprintf("The page is %d KiB and was served without compression; enabling gzip, brotli or zstd would reduce transfer size.",
+				p.UncompressedSize>>10),
+		})
+	}
+	return findings
+}
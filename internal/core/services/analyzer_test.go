@@ -2,11 +2,21 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
 )
 
@@ -14,41 +24,49 @@ type MockHTTPClient struct {
 	mock.Mock
 }
 
-func (m *MockHTTPClient) FetchPage(ctx context.Context, url string) (string, error) {
-	args := m.Called(ctx, url)
-	return args.String(0), args.Error(1)
+func (m *MockHTTPClient) FetchPage(ctx context.Context, url string, opts domain.FetchOptions) (*domain.FetchResult, error) {
+	args := m.Called(ctx, url, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FetchResult), args.Error(1)
 }
 
-func (m *MockHTTPClient) CheckLink(ctx context.Context, url string) bool {
+func (m *MockHTTPClient) CheckLink(ctx context.Context, url string) domain.LinkCheckResult {
 	args := m.Called(ctx, url)
-	return args.Bool(0)
+	return args.Get(0).(domain.LinkCheckResult)
 }
 
 type MockHTMLParser struct {
 	mock.Mock
 }
 
-func (m *MockHTMLParser) GetHTMLVersion(doc string) string {
+func (m *MockHTMLParser) GetHTMLVersion(ctx context.Context, doc string) string {
 	args := m.Called(doc)
 	return args.String(0)
 }
 
-func (m *MockHTMLParser) GetTitle(doc string) string {
+func (m *MockHTMLParser) GetTitle(ctx context.Context, doc string) string {
 	args := m.Called(doc)
 	return args.String(0)
 }
 
-func (m *MockHTMLParser) CountHeadings(doc string) domain.HeadingCount {
+func (m *MockHTMLParser) CountHeadings(ctx context.Context, doc string) domain.HeadingCount {
 	args := m.Called(doc)
 	return args.Get(0).(domain.HeadingCount)
 }
 
-func (m *MockHTMLParser) AnalyzeLinks(doc string, baseURL string) domain.LinkAnalysis {
+func (m *MockHTMLParser) AnalyzeLinks(ctx context.Context, doc string, baseURL string) domain.LinkAnalysis {
 	args := m.Called(doc, baseURL)
 	return args.Get(0).(domain.LinkAnalysis)
 }
 
-func (m *MockHTMLParser) HasLoginForm(doc string) bool {
+func (m *MockHTMLParser) ExtractLinks(ctx context.Context, doc string, baseURL string) []string {
+	args := m.Called(doc, baseURL)
+	return args.Get(0).([]string)
+}
+
+func (m *MockHTMLParser) HasLoginForm(ctx context.Context, doc string) bool {
 	args := m.Called(doc)
 	return args.Bool(0)
 }
@@ -61,6 +79,7 @@ func TestAnalyzerService_Analyze(t *testing.T) {
 	tests := []struct {
 		name           string
 		url            string
+		options        domain.FetchOptions
 		setupMocks     func(*MockHTTPClient, *MockHTMLParser)
 		expectedError  error
 		expectedResult *domain.PageAnalysis
@@ -70,8 +89,12 @@ func TestAnalyzerService_Analyze(t *testing.T) {
 			url:  "https://example.com",
 			setupMocks: func(httpClient *MockHTTPClient, htmlParser *MockHTMLParser) {
 				// Setup HTTP client expectations
-				httpClient.On("FetchPage", mock.Anything, "https://example.com").
-					Return("<html></html>", nil)
+				httpClient.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(&domain.FetchResult{
+						Body:       "<html></html>",
+						StatusCode: 200,
+						Redirects:  domain.RedirectAnalysis{FinalURL: "https://example.com"},
+					}, nil)
 
 				// Setup HTML parser expectations
 				htmlParser.On("GetHTMLVersion", "<html></html>").
@@ -92,6 +115,43 @@ func TestAnalyzerService_Analyze(t *testing.T) {
 				Headings:     domain.HeadingCount{H1: 1},
 				Links:        domain.LinkAnalysis{Internal: 1},
 				HasLoginForm: false,
+				Redirects:    domain.RedirectAnalysis{FinalURL: "https://example.com"},
+			},
+		},
+		{
+			name: "Links resolved against redirect target of truncated page",
+			url:  "http://example.com",
+			setupMocks: func(httpClient *MockHTTPClient, htmlParser *MockHTMLParser) {
+				redirects := domain.RedirectAnalysis{
+					Chain: []domain.RedirectHop{
+						{URL: "http://example.com", StatusCode: 301, Location: "https://www.example.com/"},
+					},
+					FinalURL:     "https://www.example.com/",
+					HTTPSUpgrade: true,
+				}
+				httpClient.On("FetchPage", mock.Anything, "http://example.com", mock.Anything).
+					Return(&domain.FetchResult{Body: "<html></html>", StatusCode: 200, Redirects: redirects, Truncated: true}, nil)
+
+				htmlParser.On("GetHTMLVersion", "<html></html>").Return("HTML5")
+				htmlParser.On("GetTitle", "<html></html>").Return("Example Title")
+				htmlParser.On("CountHeadings", "<html></html>").Return(domain.HeadingCount{})
+				htmlParser.On("AnalyzeLinks", "<html></html>", "https://www.example.com/").
+					Return(domain.LinkAnalysis{External: 2})
+				htmlParser.On("HasLoginForm", "<html></html>").Return(false)
+			},
+			expectedError: nil,
+			expectedResult: &domain.PageAnalysis{
+				HTMLVersion: "HTML5",
+				PageTitle:   "Example Title",
+				Links:       domain.LinkAnalysis{External: 2},
+				Redirects: domain.RedirectAnalysis{
+					Chain: []domain.RedirectHop{
+						{URL: "http://example.com", StatusCode: 301, Location: "https://www.example.com/"},
+					},
+					FinalURL:     "https://www.example.com/",
+					HTTPSUpgrade: true,
+				},
+				Truncated: true,
 			},
 		},
 		{
@@ -102,16 +162,57 @@ func TestAnalyzerService_Analyze(t *testing.T) {
 			expectedError:  domain.ErrInvalidURL,
 			expectedResult: nil,
 		},
+		{
+			name: "Invalid fetch options",
+			url:  "https://example.com",
+			options: domain.FetchOptions{
+				Headers: map[string]string{"Host": "other.example"},
+			},
+			setupMocks: func(httpClient *MockHTTPClient, htmlParser *MockHTMLParser) {
+			},
+			expectedError:  domain.ErrValidationFailed,
+			expectedResult: nil,
+		},
 		{
 			name: "Page not accessible",
 			url:  "https://example.com",
 			setupMocks: func(httpClient *MockHTTPClient, htmlParser *MockHTMLParser) {
-				httpClient.On("FetchPage", mock.Anything, "https://example.com").
-					Return("", domain.ErrPageNotAccessible)
+				httpClient.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, domain.ErrPageNotAccessible)
 			},
 			expectedError:  domain.ErrPageNotAccessible,
 			expectedResult: nil,
 		},
+		{
+			name: "Classified fetch error passes through",
+			url:  "https://example.com",
+			setupMocks: func(httpClient *MockHTTPClient, htmlParser *MockHTMLParser) {
+				httpClient.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, domain.ErrDNSResolutionFailed.WithCause(errors.New("no such host")))
+			},
+			expectedError:  domain.ErrDNSResolutionFailed,
+			expectedResult: nil,
+		},
+		{
+			name: "Wrapped deadline maps to timeout",
+			url:  "https://example.com",
+			setupMocks: func(httpClient *MockHTTPClient, htmlParser *MockHTMLParser) {
+				httpClient.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, fmt.Errorf("fetch: %w", context.DeadlineExceeded))
+			},
+			expectedError:  domain.ErrTimeout,
+			expectedResult: nil,
+		},
+		{
+			name: "Redirect loop",
+			url:  "https://example.com",
+			setupMocks: func(httpClient *MockHTTPClient, htmlParser *MockHTMLParser) {
+				httpClient.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, domain.ErrRedirectLoop)
+			},
+			expectedError:  domain.ErrRedirectLoop,
+			expectedResult: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,13 +222,13 @@ func TestAnalyzerService_Analyze(t *testing.T) {
 
 			tt.setupMocks(httpClient, htmlParser)
 
-			service := NewAnalyzerService(httpClient, htmlParser, logger)
+			service := NewAnalyzerService(httpClient, htmlParser, LinkCheckPolicy{}, logger)
 
-			result, err := service.Analyze(context.Background(), tt.url)
+			result, err := service.Analyze(context.Background(), domain.AnalysisRequest{URL: tt.url, FetchOptions: tt.options})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
-				assert.Equal(t, tt.expectedError, err)
+				assert.ErrorIs(t, err, tt.expectedError)
 				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
@@ -140,3 +241,218 @@ func TestAnalyzerService_Analyze(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzerService_LinkChecks(t *testing.T) {
+	const html = "<html></html>"
+	links := []string{"https://example.com/ok", "https://example.com/gone", "https://example.com/private", "https://example.com/extra"}
+	checks := []domain.LinkCheckResult{
+		{URL: links[0], Status: domain.LinkReachable, StatusCode: 200, Method: "HEAD"},
+		{URL: links[1], Status: domain.LinkBroken, StatusCode: 404, Method: "HEAD"},
+		{URL: links[2], Status: domain.LinkRestricted, StatusCode: 401, Method: "HEAD"},
+	}
+
+	var inFlight, peak int32
+	httpClient := new(MockHTTPClient)
+	httpClient.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+		Return(&domain.FetchResult{Body: html}, nil)
+	for _, c := range checks {
+		httpClient.On("CheckLink", mock.Anything, c.URL).
+			Run(func(mock.Arguments) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			}).
+			Return(c)
+	}
+	htmlParser := new(MockHTMLParser)
+	htmlParser.On("GetHTMLVersion", html).Return("HTML5")
+	htmlParser.On("GetTitle", html).Return("")
+	htmlParser.On("CountHeadings", html).Return(domain.HeadingCount{})
+	htmlParser.On("AnalyzeLinks", html, "https://example.com").Return(domain.LinkAnalysis{Internal: 4, Inaccessible: 1})
+	htmlParser.On("ExtractLinks", html, "https://example.com").Return(links)
+	htmlParser.On("HasLoginForm", html).Return(false)
+
+	service := NewAnalyzerService(httpClient, htmlParser, LinkCheckPolicy{MaxLinks: 3, Concurrency: 2}, zap.NewNop())
+	result, err := service.Analyze(context.Background(), domain.AnalysisRequest{URL: "https://example.com"})
+
+	assert.NoError(t, err)
+	// The unparseable link counted by the parser plus the broken one; the
+	// restricted link exists and the fourth is over the limit
+	assert.Equal(t, domain.LinkAnalysis{Internal: 4, Inaccessible: 2, Checks: checks, Unchecked: 1}, result.Links)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+	httpClient.AssertExpectations(t)
+}
+
+func TestAnalyzerService_AnalyzeHTML(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	const html = "<html><title>Preview</title></html>"
+
+	tests := []struct {
+		name           string
+		request        domain.HTMLAnalysisRequest
+		setupMocks     func(*MockHTMLParser)
+		expectedError  error
+		expectedResult *domain.PageAnalysis
+	}{
+		{
+			name:    "Analyzes submitted HTML",
+			request: domain.HTMLAnalysisRequest{HTML: html, BaseURL: "https://staging.example.com"},
+			setupMocks: func(htmlParser *MockHTMLParser) {
+				htmlParser.On("GetHTMLVersion", html).Return("Unknown")
+				htmlParser.On("GetTitle", html).Return("Preview")
+				htmlParser.On("CountHeadings", html).Return(domain.HeadingCount{})
+				htmlParser.On("AnalyzeLinks", html, "https://staging.example.com").
+					Return(domain.LinkAnalysis{Internal: 3})
+				htmlParser.On("HasLoginForm", html).Return(true)
+			},
+			expectedResult: &domain.PageAnalysis{
+				HTMLVersion:  "Unknown",
+				PageTitle:    "Preview",
+				Links:        domain.LinkAnalysis{Internal: 3},
+				HasLoginForm: true,
+				Redirects: domain.RedirectAnalysis{
+					Chain:    []domain.RedirectHop{},
+					FinalURL: "https://staging.example.com",
+				},
+			},
+		},
+		{
+			name:          "Invalid base URL",
+			request:       domain.HTMLAnalysisRequest{HTML: html, BaseURL: "not a url"},
+			setupMocks:    func(htmlParser *MockHTMLParser) {},
+			expectedError: domain.ErrInvalidURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The HTTP client must never be used when HTML is supplied
+			httpClient := new(MockHTTPClient)
+			htmlParser := new(MockHTMLParser)
+			tt.setupMocks(htmlParser)
+
+			service := NewAnalyzerService(httpClient, htmlParser, LinkCheckPolicy{}, logger)
+			result, err := service.AnalyzeHTML(context.Background(), tt.request)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+
+			httpClient.AssertExpectations(t)
+			htmlParser.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPerformanceFindings(t *testing.T) {
+	tests := []struct {
+		name        string
+		performance domain.PerformanceMetrics
+		expected    []string
+	}{
+		{
+			name:        "Large uncompressed page",
+			performance: domain.PerformanceMetrics{ContentEncoding: "identity", UncompressedSize: 64 << 10},
+			expected:    []string{domain.FindingUncompressedText},
+		},
+		{
+			name:        "Small uncompressed page",
+			performance: domain.PerformanceMetrics{ContentEncoding: "identity", UncompressedSize: 2 << 10},
+		},
+		{
+			name:        "Large compressed page",
+			performance: domain.PerformanceMetrics{ContentEncoding: "br", UncompressedSize: 64 << 10, CompressedSize: 8 << 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var codes []string
+			for _, f := range performanceFindings(tt.performance) {
+				codes = append(codes, f.Code)
+			}
+			assert.Equal(t, tt.expected, codes)
+		})
+	}
+}
+
+func TestAnalyzerService_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	const html = "<html></html>"
+	httpClient := new(MockHTTPClient)
+	httpClient.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+		Return(&domain.FetchResult{Body: html}, nil)
+	httpClient.On("FetchPage", mock.Anything, "https://down.example.com", mock.Anything).
+		Return(nil, domain.ErrConnectionRefused)
+	htmlParser := new(MockHTMLParser)
+	htmlParser.On("GetHTMLVersion", html).Return("HTML5")
+	htmlParser.On("GetTitle", html).Return("")
+	htmlParser.On("CountHeadings", html).Return(domain.HeadingCount{})
+	htmlParser.On("AnalyzeLinks", html, "https://example.com").Return(domain.LinkAnalysis{})
+	htmlParser.On("HasLoginForm", html).Return(false)
+
+	service := NewAnalyzerService(httpClient, htmlParser, LinkCheckPolicy{}, zap.NewNop())
+	_, err := service.Analyze(context.Background(), domain.AnalysisRequest{URL: "https://example.com"})
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	names := make([]string, 0, len(spans))
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	assert.Equal(t, []string{
+		"parser.GetHTMLVersion", "parser.GetTitle", "parser.CountHeadings",
+		"parser.AnalyzeLinks", "parser.HasLoginForm", "Analyze",
+	}, names)
+
+	root := spans[len(spans)-1]
+	for _, s := range spans[:len(spans)-1] {
+		assert.Equal(t, root.SpanContext().SpanID(), s.Parent().SpanID(), s.Name())
+	}
+
+	_, err = service.Analyze(context.Background(), domain.AnalysisRequest{URL: "https://down.example.com"})
+	assert.Error(t, err)
+	failed := recorder.Ended()[len(spans)]
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Contains(t, failed.Attributes(), attribute.String("error.type", domain.ErrConnectionRefused.Code))
+
+	// Link checks run under the analysis, so their client spans join its trace
+	const link = "https://example.com/about"
+	httpClient.On("CheckLink", mock.Anything, link).
+		Return(domain.LinkCheckResult{URL: link, Status: domain.LinkReachable, StatusCode: 200, Method: "HEAD"})
+	htmlParser.On("ExtractLinks", html, "https://example.com").Return([]string{link})
+
+	recorded := len(recorder.Ended())
+	service = NewAnalyzerService(tracing.TraceClient(httpClient), htmlParser, LinkCheckPolicy{MaxLinks: 10, Concurrency: 1}, zap.NewNop())
+	_, err = service.Analyze(context.Background(), domain.AnalysisRequest{URL: "https://example.com"})
+	assert.NoError(t, err)
+
+	var analyze, check sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended()[recorded:] {
+		switch s.Name() {
+		case "Analyze":
+			analyze = s
+		case "CheckLink":
+			check = s
+		}
+	}
+	if assert.NotNil(t, check, "link checks must be traced") && assert.NotNil(t, analyze) {
+		assert.Equal(t, analyze.SpanContext().TraceID(), check.SpanContext().TraceID())
+		assert.Contains(t, check.Attributes(), attribute.String("webanalyzer.link_status", domain.LinkReachable))
+	}
+}
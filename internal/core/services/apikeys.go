@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// apiKeyPrefix marks strings as keys for this API, which helps secret scanners
+	apiKeyPrefix = "wpa_"
+	// displayPrefixLen is how much of a key is kept to identify it in listings
+	displayPrefixLen = len(apiKeyPrefix) + 8
+)
+
+// APIKeyDefaults are the limits given to keys created without explicit ones
+type APIKeyDefaults struct {
+	RateLimitPerMinute int
+	DailyQuota         int
+}
+
+type apiKeyService struct {
+	store    ports.APIKeyStore
+	defaults APIKeyDefaults
+	logger   *zap.Logger
+	now      func() time.Time
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+
+	// quotaMu makes checking and counting a request against a quota atomic
+	quotaMu sync.Mutex
+}
+
+func NewAPIKeyService(store ports.APIKeyStore, defaults APIKeyDefaults, logger *zap.Logger) ports.APIKeyManager {
+	return &apiKeyService{
+		store:    store,
+		defaults: defaults,
+		logger:   logger,
+		now:      time.Now,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (s *apiKeyService) Create(ctx context.Context, req domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error) {
+	raw, err := generateAPIKey()
+	if err != nil {
+		return nil, domain.ErrInternalServer.WithCause(err)
+	}
+
+	key := s.newKey(req.Name, raw, req.Role)
+	if req.RateLimitPerMinute != nil {
+		key.RateLimitPerMinute = *req.RateLimitPerMinute
+	}
+	if req.DailyQuota != nil {
+		key.DailyQuota = *req.DailyQuota
+	}
+
+	if err := s.store.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx, s.logger).Info("api key created",
+		zap.String("key_id", key.ID),
+		zap.String("name", key.Name),
+		zap.String("role", key.Role))
+
+	return &domain.CreatedAPIKey{APIKey: key, Key: raw}, nil
+}
+
+func (s *apiKeyService) Register(ctx context.Context, name, rawKey, role string) (*domain.APIKey, error) {
+	if existing, err := s.store.FindByHash(ctx, hashAPIKey(rawKey)); err == nil {
+		return existing, nil
+	}
+
+	// Registered keys come from trusted configuration and are not limited
+	key := s.newKey(name, rawKey, role)
+	key.RateLimitPerMinute = 0
+	key.DailyQuota = 0
+
+	if err := s.store.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context) ([]domain.APIKey, error) {
+	return s.store.List(ctx)
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, id string) error {
+	if err := s.store.Revoke(ctx, id, s.now()); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.limiters, id)
+	s.mu.Unlock()
+
+	logging.FromContext(ctx, s.logger).Info("api key revoked", zap.String("key_id", id))
+	return nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	if rawKey == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	key, err := s.store.FindByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if key.Revoked() {
+		return nil, domain.ErrUnauthorized
+	}
+	return key, nil
+}
+
+// Allow counts the request against the quota as soon as it is admitted, so
+// that concurrent requests cannot all pass the check. Requests that end up
+// rejected are given back with Refund.
+func (s *apiKeyService) Allow(ctx context.Context, key domain.APIKey) error {
+	if wait := s.reserve(key); wait > 0 {
+		return domain.ErrRateLimited.WithRetryAfter(wait)
+	}
+	if key.DailyQuota <= 0 {
+		return nil
+	}
+
+	s.quotaMu.Lock()
+	defer s.quotaMu.Unlock()
+
+	now := s.now().UTC()
+	day := now.Format(time.DateOnly)
+	used, err := s.store.Usage(ctx, key.ID, day)
+	if err != nil {
+		return err
+	}
+	if used >= int64(key.DailyQuota) {
+		midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+		return domain.ErrQuotaExceeded.WithRetryAfter(midnight.Sub(now))
+	}
+	_, err = s.store.IncrementUsage(ctx, key.ID, day)
+	return err
+}
+
+func (s *apiKeyService) Refund(ctx context.Context, key domain.APIKey) error {
+	if key.DailyQuota <= 0 {
+		return nil
+	}
+	return s.store.DecrementUsage(ctx, key.ID, s.now().UTC().Format(time.DateOnly))
+}
+
+// reserve takes a token from the key's rate limiter and returns how long the
+// caller has to wait when none is available
+func (s *apiKeyService) reserve(key domain.APIKey) time.Duration {
+	if key.RateLimitPerMinute <= 0 {
+		return 0
+	}
+
+	s.mu.Lock()
+	limiter, ok := s.limiters[key.ID]
+	if !ok {
+		// A full minute's allowance may be used at once
+		limiter = rate.NewLimiter(rate.Limit(float64(key.RateLimitPerMinute)/60), key.RateLimitPerMinute)
+		s.limiters[key.ID] = limiter
+	}
+	s.mu.Unlock()
+
+	r := limiter.Reserve()
+	if delay := r.Delay(); delay > 0 {
+		r.Cancel()
+		return delay
+	}
+	return 0
+}
+
+func (s *apiKeyService) newKey(name, raw, role string) domain.APIKey {
+	if role == "" {
+		role = domain.RoleClient
+	}
+	return domain.APIKey{
+		ID:                 newKeyID(),
+		Name:               name,
+		Prefix:             displayPrefix(raw),
+		Hash:               hashAPIKey(raw),
+		Role:               role,
+		RateLimitPerMinute: s.defaults.RateLimitPerMinute,
+		DailyQuota:         s.defaults.DailyQuota,
+		CreatedAt:          s.now().UTC(),
+	}
+}
+
+// generateAPIKey returns a new key with 256 bits of entropy
+func generateAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPIKey hashes a key for storage. Keys are random, so a fast hash is
+// enough to make a leaked store useless without slowing every request down.
+func hashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func newKeyID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// displayPrefix returns the start of a key, never more than a third of it so
+// that short configured keys are not given away
+func displayPrefix(raw string) string {
+	return raw[:min(displayPrefixLen, len(raw)/3)]
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/store/memory"
+	"go.uber.org/zap"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestAPIKeyService_CreateAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewAPIKeyStore()
+	service := NewAPIKeyService(store, APIKeyDefaults{RateLimitPerMinute: 60, DailyQuota: 100}, zap.NewNop())
+
+	created, err := service.Create(ctx, domain.CreateAPIKeyRequest{Name: "ci"})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, apiKeyPrefix))
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	assert.Equal(t, domain.RoleClient, created.Role)
+	assert.Equal(t, 60, created.RateLimitPerMinute)
+	assert.Equal(t, 100, created.DailyQuota)
+
+	// Only the hash is stored
+	stored, err := store.Get(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.NotContains(t, stored.Hash, created.Key)
+	assert.Equal(t, hashAPIKey(created.Key), stored.Hash)
+
+	key, err := service.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, key.ID)
+
+	_, err = service.Authenticate(ctx, "wpa_unknown")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	_, err = service.Authenticate(ctx, "")
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	assert.NoError(t, service.Revoke(ctx, created.ID))
+	_, err = service.Authenticate(ctx, created.Key)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	assert.ErrorIs(t, service.Revoke(ctx, "missing"), domain.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyService_RateLimit(t *testing.T) {
+	ctx := context.Background()
+	service := NewAPIKeyService(memory.NewAPIKeyStore(), APIKeyDefaults{}, zap.NewNop())
+
+	created, err := service.Create(ctx, domain.CreateAPIKeyRequest{Name: "burst", RateLimitPerMinute: intPtr(2)})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, service.Allow(ctx, created.APIKey))
+	}
+
+	err = service.Allow(ctx, created.APIKey)
+	assert.ErrorIs(t, err, domain.ErrRateLimited)
+
+	var apiErr *domain.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.InDelta(t, 30*time.Second, apiErr.RetryAfter, float64(time.Second))
+	}
+
+	// Authentication itself is not limited
+	key, err := service.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, key.ID)
+}
+
+func TestAPIKeyService_DailyQuota(t *testing.T) {
+	ctx := context.Background()
+	service := NewAPIKeyService(memory.NewAPIKeyStore(), APIKeyDefaults{}, zap.NewNop()).(*apiKeyService)
+	service.now = func() time.Time { return time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC) }
+
+	created, err := service.Create(ctx, domain.CreateAPIKeyRequest{Name: "quota", DailyQuota: intPtr(2)})
+	assert.NoError(t, err)
+
+	// Requests that are rejected after being allowed are refunded
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, service.Allow(ctx, created.APIKey))
+		assert.NoError(t, service.Refund(ctx, created.APIKey))
+	}
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, service.Allow(ctx, created.APIKey))
+	}
+
+	err = service.Allow(ctx, created.APIKey)
+	assert.ErrorIs(t, err, domain.ErrQuotaExceeded)
+	var apiErr *domain.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, 6*time.Hour, apiErr.RetryAfter)
+	}
+
+	// The quota resets the next day
+	service.now = func() time.Time { return time.Date(2026, 3, 2, 0, 0, 1, 0, time.UTC) }
+	assert.NoError(t, service.Allow(ctx, created.APIKey))
+}
+
+func TestAPIKeyService_DailyQuotaConcurrent(t *testing.T) {
+	ctx := context.Background()
+	service := NewAPIKeyService(memory.NewAPIKeyStore(), APIKeyDefaults{}, zap.NewNop())
+
+	created, err := service.Create(ctx, domain.CreateAPIKeyRequest{Name: "quota", DailyQuota: intPtr(3)})
+	assert.NoError(t, err)
+
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if service.Allow(ctx, created.APIKey) == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(3), allowed.Load())
+}
+
+func TestAPIKeyService_Register(t *testing.T) {
+	ctx := context.Background()
+	service := NewAPIKeyService(memory.NewAPIKeyStore(), APIKeyDefaults{RateLimitPerMinute: 1, DailyQuota: 1}, zap.NewNop())
+
+	first, err := service.Register(ctx, "bootstrap admin", "configured-admin-key", domain.RoleAdmin)
+	assert.NoError(t, err)
+	second, err := service.Register(ctx, "bootstrap admin", "configured-admin-key", domain.RoleAdmin)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	// Short configured keys only reveal a third of their length
+	assert.Equal(t, "config", first.Prefix)
+
+	// Registered keys are not limited by the defaults
+	for i := 0; i < 3; i++ {
+		key, err := service.Authenticate(ctx, "configured-admin-key")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, key.Role)
+		assert.NoError(t, service.Allow(ctx, *key))
+	}
+}
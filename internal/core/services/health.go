@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+)
+
+// errDraining fails readiness while the server shuts down
+var errDraining = errors.New("server is shutting down")
+
+// HealthService is a registry of liveness and readiness checks. Readiness
+// also fails once Drain is called, so that load balancers stop sending
+// traffic before the server stops accepting it.
+type HealthService struct {
+	timeout  time.Duration
+	draining atomic.Bool
+
+	mu       sync.RWMutex
+	liveness []ports.HealthCheck
+	ready    []ports.HealthCheck
+}
+
+// NewHealthService creates a registry whose checks each get at most timeout
+func NewHealthService(timeout time.Duration) *HealthService {
+	h := &HealthService{timeout: timeout}
+	h.ready = append(h.ready, drainCheck{h})
+	return h
+}
+
+// RegisterLiveness adds a check whose failure means the process should be
+// restarted. Most dependencies belong in readiness instead.
+func (h *HealthService) RegisterLiveness(check ports.HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.liveness = append(h.liveness, check)
+}
+
+// RegisterReadiness adds a check whose failure means traffic should be
+// routed elsewhere until it recovers
+func (h *HealthService) RegisterReadiness(check ports.HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ready = append(h.ready, check)
+}
+
+// Drain marks the server as shutting down
+func (h *HealthService) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *HealthService) Live(ctx context.Context) domain.HealthReport {
+	h.mu.RLock()
+	checks := append([]ports.HealthCheck(nil), h.liveness...)
+	h.mu.RUnlock()
+	return h.run(ctx, checks)
+}
+
+func (h *HealthService) Ready(ctx context.Context) domain.HealthReport {
+	h.mu.RLock()
+	checks := append([]ports.HealthCheck(nil), h.ready...)
+	h.mu.RUnlock()
+	return h.run(ctx, checks)
+}
+
+// run executes checks concurrently, each with its own timeout
+func (h *HealthService) run(ctx context.Context, checks []ports.HealthCheck) domain.HealthReport {
+	report := domain.HealthReport{
+		Status: domain.HealthUp,
+		Checks: make([]domain.HealthCheckResult, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check ports.HealthCheck) {
+			defer wg.Done()
+			report.Checks[i] = h.runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != domain.HealthUp {
+			report.Status = domain.HealthDown
+		}
+	}
+	return report
+}
+
+func (h *HealthService) runCheck(ctx context.Context, check ports.HealthCheck) domain.HealthCheckResult {
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	details, err := check.Check(ctx)
+	result := domain.HealthCheckResult{
+		Name:      check.Name(),
+		Status:    domain.HealthUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		Details:   details,
+	}
+	if err != nil {
+		result.Status = domain.HealthDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// drainCheck fails once the registry is draining
+type drainCheck struct {
+	health *HealthService
+}
+
+func (d drainCheck) Name() string { return "drain" }
+
+func (d drainCheck) Check(ctx context.Context) (map[string]any, error) {
+	if d.health.draining.Load() {
+		return nil, errDraining
+	}
+	return nil, nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// stubCheck returns fixed results, optionally after waiting for the context
+type stubCheck struct {
+	name    string
+	details map[string]any
+	err     error
+	block   bool
+}
+
+func (s stubCheck) Name() string { return s.name }
+
+func (s stubCheck) Check(ctx context.Context) (map[string]any, error) {
+	if s.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return s.details, s.err
+}
+
+func TestHealthService(t *testing.T) {
+	tests := []struct {
+		name           string
+		readiness      []stubCheck
+		drain          bool
+		expectedStatus string
+		expectedChecks map[string]string
+		expectedErrors map[string]string
+	}{
+		{
+			name:           "All checks up",
+			readiness:      []stubCheck{{name: "store", details: map[string]any{"keys": 2}}},
+			expectedStatus: domain.HealthUp,
+			expectedChecks: map[string]string{"drain": domain.HealthUp, "store": domain.HealthUp},
+		},
+		{
+			name:           "Failing check",
+			readiness:      []stubCheck{{name: "store"}, {name: "egress", err: errors.New("dns lookup failed")}},
+			expectedStatus: domain.HealthDown,
+			expectedChecks: map[string]string{"drain": domain.HealthUp, "store": domain.HealthUp, "egress": domain.HealthDown},
+			expectedErrors: map[string]string{"egress": "dns lookup failed"},
+		},
+		{
+			name:           "Slow check times out",
+			readiness:      []stubCheck{{name: "store", block: true}},
+			expectedStatus: domain.HealthDown,
+			expectedChecks: map[string]string{"drain": domain.HealthUp, "store": domain.HealthDown},
+			expectedErrors: map[string]string{"store": context.DeadlineExceeded.Error()},
+		},
+		{
+			name:           "Draining",
+			drain:          true,
+			expectedStatus: domain.HealthDown,
+			expectedChecks: map[string]string{"drain": domain.HealthDown},
+			expectedErrors: map[string]string{"drain": errDraining.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthService(50 * time.Millisecond)
+			for _, check := range tt.readiness {
+				h.RegisterReadiness(check)
+			}
+			if tt.drain {
+				h.Drain()
+			}
+
+			report := h.Ready(context.Background())
+
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			checks := make(map[string]string)
+			for _, result := range report.Checks {
+				checks[result.Name] = result.Status
+				assert.Equal(t, tt.expectedErrors[result.Name], result.Error, result.Name)
+				assert.GreaterOrEqual(t, result.LatencyMs, 0.0)
+			}
+			assert.Equal(t, tt.expectedChecks, checks)
+
+			// Dependencies and draining never fail liveness
+			assert.Equal(t, domain.HealthUp, h.Live(context.Background()).Status)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: analyzer/v1/analyzer.proto
+
+package analyzerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyzerService_Analyze_FullMethodName       = "/webanalyzer.v1.AnalyzerService/Analyze"
+	AnalyzerService_AnalyzeHTML_FullMethodName   = "/webanalyzer.v1.AnalyzerService/AnalyzeHTML"
+	AnalyzerService_AnalyzeBatch_FullMethodName  = "/webanalyzer.v1.AnalyzerService/AnalyzeBatch"
+	AnalyzerService_AnalyzeStream_FullMethodName = "/webanalyzer.v1.AnalyzerService/AnalyzeStream"
+)
+
+// AnalyzerServiceClient is the client API for AnalyzerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AnalyzerService analyzes web pages with the same analyzer as the REST API.
+//
+// Failed calls carry a google.rpc.Status whose details include an ErrorInfo
+// with the REST error code as its reason, a BadRequest listing invalid fields
+// and a RetryInfo when the caller should wait before trying again.
+type AnalyzerServiceClient interface {
+	// Analyze fetches and analyzes one page, like POST /analyze.
+	Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*PageAnalysis, error)
+	// AnalyzeHTML analyzes HTML supplied by the caller, like POST /analyze/html.
+	AnalyzeHTML(ctx context.Context, in *AnalyzeHTMLRequest, opts ...grpc.CallOption) (*PageAnalysis, error)
+	// AnalyzeBatch analyzes several pages concurrently and returns once all of
+	// them are done. Pages that fail are reported in their result rather than
+	// failing the call.
+	AnalyzeBatch(ctx context.Context, in *AnalyzeBatchRequest, opts ...grpc.CallOption) (*AnalyzeBatchResponse, error)
+	// AnalyzeStream analyzes several pages concurrently and reports progress as
+	// each page starts and finishes. Cancelling the call stops pending pages.
+	AnalyzeStream(ctx context.Context, in *AnalyzeBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalysisProgress], error)
+}
+
+type analyzerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyzerServiceClient(cc grpc.ClientConnInterface) AnalyzerServiceClient {
+	return &analyzerServiceClient{cc}
+}
+
+func (c *analyzerServiceClient) Analyze(ctx context.Context, in *AnalyzeRequest, opts ...grpc.CallOption) (*PageAnalysis, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PageAnalysis)
+	err := c.cc.Invoke(ctx, AnalyzerService_Analyze_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerServiceClient) AnalyzeHTML(ctx context.Context, in *AnalyzeHTMLRequest, opts ...grpc.CallOption) (*PageAnalysis, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PageAnalysis)
+	err := c.cc.Invoke(ctx, AnalyzerService_AnalyzeHTML_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerServiceClient) AnalyzeBatch(ctx context.Context, in *AnalyzeBatchRequest, opts ...grpc.CallOption) (*AnalyzeBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyzeBatchResponse)
+	err := c.cc.Invoke(ctx, AnalyzerService_AnalyzeBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyzerServiceClient) AnalyzeStream(ctx context.Context, in *AnalyzeBatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AnalysisProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AnalyzerService_ServiceDesc.Streams[0], AnalyzerService_AnalyzeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AnalyzeBatchRequest, AnalysisProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalyzerService_AnalyzeStreamClient = grpc.ServerStreamingClient[AnalysisProgress]
+
+// AnalyzerServiceServer is the server API for AnalyzerService service.
+// All implementations must embed UnimplementedAnalyzerServiceServer
+// for forward compatibility.
+//
+// AnalyzerService analyzes web pages with the same analyzer as the REST API.
+//
+// Failed calls carry a google.rpc.Status whose details include an ErrorInfo
+// with the REST error code as its reason, a BadRequest listing invalid fields
+// and a RetryInfo when the caller should wait before trying again.
+type AnalyzerServiceServer interface {
+	// Analyze fetches and analyzes one page, like POST /analyze.
+	Analyze(context.Context, *AnalyzeRequest) (*PageAnalysis, error)
+	// AnalyzeHTML analyzes HTML supplied by the caller, like POST /analyze/html.
+	AnalyzeHTML(context.Context, *AnalyzeHTMLRequest) (*PageAnalysis, error)
+	// AnalyzeBatch analyzes several pages concurrently and returns once all of
+	// them are done. Pages that fail are reported in their result rather than
+	// failing the call.
+	AnalyzeBatch(context.Context, *AnalyzeBatchRequest) (*AnalyzeBatchResponse, error)
+	// AnalyzeStream analyzes several pages concurrently and reports progress as
+	// each page starts and finishes. Cancelling the call stops pending pages.
+	AnalyzeStream(*AnalyzeBatchRequest, grpc.ServerStreamingServer[AnalysisProgress]) error
+	mustEmbedUnimplementedAnalyzerServiceServer()
+}
+
+// UnimplementedAnalyzerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyzerServiceServer struct{}
+
+func (UnimplementedAnalyzerServiceServer) Analyze(context.Context, *AnalyzeRequest) (*PageAnalysis, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Analyze not implemented")
+}
+func (UnimplementedAnalyzerServiceServer) AnalyzeHTML(context.Context, *AnalyzeHTMLRequest) (*PageAnalysis, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeHTML not implemented")
+}
+func (UnimplementedAnalyzerServiceServer) AnalyzeBatch(context.Context, *AnalyzeBatchRequest) (*AnalyzeBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeBatch not implemented")
+}
+func (UnimplementedAnalyzerServiceServer) AnalyzeStream(*AnalyzeBatchRequest, grpc.ServerStreamingServer[AnalysisProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method AnalyzeStream not implemented")
+}
+func (UnimplementedAnalyzerServiceServer) mustEmbedUnimplementedAnalyzerServiceServer() {}
+func (UnimplementedAnalyzerServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeAnalyzerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyzerServiceServer will
+// result in compilation errors.
+type UnsafeAnalyzerServiceServer interface {
+	mustEmbedUnimplementedAnalyzerServiceServer()
+}
+
+func RegisterAnalyzerServiceServer(s grpc.ServiceRegistrar, srv AnalyzerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalyzerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyzerService_ServiceDesc, srv)
+}
+
+func _AnalyzerService_Analyze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServiceServer).Analyze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyzerService_Analyze_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServiceServer).Analyze(ctx, req.(*AnalyzeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyzerService_AnalyzeHTML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeHTMLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServiceServer).AnalyzeHTML(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyzerService_AnalyzeHTML_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServiceServer).AnalyzeHTML(ctx, req.(*AnalyzeHTMLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyzerService_AnalyzeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServiceServer).AnalyzeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyzerService_AnalyzeBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServiceServer).AnalyzeBatch(ctx, req.(*AnalyzeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyzerService_AnalyzeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AnalyzeBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnalyzerServiceServer).AnalyzeStream(m, &grpc.GenericServerStream[AnalyzeBatchRequest, AnalysisProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AnalyzerService_AnalyzeStreamServer = grpc.ServerStreamingServer[AnalysisProgress]
+
+// AnalyzerService_ServiceDesc is the grpc.ServiceDesc for AnalyzerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyzerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "webanalyzer.v1.AnalyzerService",
+	HandlerType: (*AnalyzerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Analyze",
+			Handler:    _AnalyzerService_Analyze_Handler,
+		},
+		{
+			MethodName: "AnalyzeHTML",
+			Handler:    _AnalyzerService_AnalyzeHTML_Handler,
+		},
+		{
+			MethodName: "AnalyzeBatch",
+			Handler:    _AnalyzerService_AnalyzeBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AnalyzeStream",
+			Handler:       _AnalyzerService_AnalyzeStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "analyzer/v1/analyzer.proto",
+}
@@ -0,0 +1,141 @@
+package grpcapi
+
+import (
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	pb "github.com/suraif16/webpage-analyzer/internal/grpcapi/analyzerv1"
+)
+
+func fromProtoRequest(req *pb.AnalyzeRequest) domain.AnalysisRequest {
+	out := domain.AnalysisRequest{URL: req.GetUrl()}
+	opts := req.GetOptions()
+	if opts == nil {
+		return out
+	}
+
+	out.FetchOptions = domain.FetchOptions{
+		Headers:        opts.GetHeaders(),
+		Cookies:        opts.GetCookies(),
+		UserAgent:      opts.GetUserAgent(),
+		AcceptLanguage: opts.GetAcceptLanguage(),
+		TimeoutMs:      int(opts.GetTimeoutMs()),
+		MaxBodyBytes:   opts.GetMaxBodyBytes(),
+		AllowTruncated: opts.GetAllowTruncated(),
+		Proxy:          opts.GetProxy(),
+	}
+	if opts.MaxRedirects != nil {
+		n := int(opts.GetMaxRedirects())
+		out.MaxRedirects = &n
+	}
+	if auth := opts.GetAuth(); auth != nil {
+		out.Auth = &domain.FetchAuth{
+			Type:     auth.GetType(),
+			Username: auth.GetUsername(),
+			Password: auth.GetPassword(),
+			Token:    auth.GetToken(),
+		}
+	}
+	return out
+}
+
+func toProtoAnalysis(a *domain.PageAnalysis) *pb.PageAnalysis {
+	out := &pb.PageAnalysis{
+		HtmlVersion: a.HTMLVersion,
+		PageTitle:   a.PageTitle,
+		Headings: &pb.HeadingCount{
+			H1: int32(a.Headings.H1),
+			H2: int32(a.Headings.H2),
+			H3: int32(a.Headings.H3),
+			H4: int32(a.Headings.H4),
+			H5: int32(a.Headings.H5),
+			H6: int32(a.Headings.H6),
+		},
+		Links: &pb.LinkAnalysis{
+			Internal:     int32(a.Links.Internal),
+			External:     int32(a.Links.External),
+			Inaccessible: int32(a.Links.Inaccessible),
+			Unchecked:    int32(a.Links.Unchecked),
+		},
+		HasLoginForm: a.HasLoginForm,
+		Redirects: &pb.RedirectAnalysis{
+			FinalUrl:       a.Redirects.FinalURL,
+			HttpsUpgrade:   a.Redirects.HTTPSUpgrade,
+			HttpsDowngrade: a.Redirects.HTTPSDowngrade,
+		},
+		Performance: &pb.PerformanceMetrics{
+			DnsLookupMs:       a.Performance.DNSLookupMs,
+			TcpConnectMs:      a.Performance.TCPConnectMs,
+			TlsHandshakeMs:    a.Performance.TLSHandshakeMs,
+			TimeToFirstByteMs: a.Performance.TimeToFirstByteMs,
+			ContentDownloadMs: a.Performance.ContentDownloadMs,
+			TotalMs:           a.Performance.TotalMs,
+			CompressedSize:    a.Performance.CompressedSize,
+			UncompressedSize:  a.Performance.UncompressedSize,
+			ContentEncoding:   a.Performance.ContentEncoding,
+			CompressionRatio:  a.Performance.CompressionRatio,
+			ConnectionReused:  a.Performance.ConnectionReused,
+		},
+		Content: &pb.ContentInfo{
+			ContentType:   a.Content.ContentType,
+			Charset:       a.Content.Charset,
+			CharsetSource: a.Content.CharsetSource,
+		},
+		Caching: &pb.CachingAnalysis{
+			Cacheability:  a.Caching.Cacheability,
+			Cacheable:     a.Caching.Cacheable,
+			MaxAgeSeconds: a.Caching.MaxAgeSeconds,
+			AgeSeconds:    a.Caching.AgeSeconds,
+			CacheControl:  a.Caching.CacheControl,
+			Expires:       a.Caching.Expires,
+			Etag:          a.Caching.ETag,
+			LastModified:  a.Caching.LastModified,
+			Vary:          a.Caching.Vary,
+			Via:           a.Caching.Via,
+			Cdn:           a.Caching.CDN,
+			CacheStatus:   a.Caching.CacheStatus,
+		},
+		Truncated: a.Truncated,
+	}
+
+	for _, c := range a.Links.Checks {
+		out.Links.Checks = append(out.Links.Checks, &pb.LinkCheck{
+			Url:        c.URL,
+			Status:     c.Status,
+			StatusCode: int32(c.StatusCode),
+			Method:     c.Method,
+			FinalUrl:   c.FinalURL,
+			Error:      c.Error,
+		})
+	}
+	for _, hop := range a.Redirects.Chain {
+		out.Redirects.Chain = append(out.Redirects.Chain, &pb.RedirectHop{
+			Url:        hop.URL,
+			StatusCode: int32(hop.StatusCode),
+			Location:   hop.Location,
+			LatencyMs:  hop.LatencyMs,
+		})
+	}
+	for _, f := range a.Performance.Findings {
+		out.Performance.Findings = append(out.Performance.Findings, &pb.Finding{
+			Code:     f.Code,
+			Severity: f.Severity,
+			Message:  f.Message,
+		})
+	}
+	for _, m := range a.Caching.ServerTiming {
+		out.Caching.ServerTiming = append(out.Caching.ServerTiming, &pb.ServerTimingMetric{
+			Name:        m.Name,
+			DurationMs:  m.DurationMs,
+			Description: m.Description,
+		})
+	}
+	if a.Cache != nil {
+		out.Cache = &pb.CacheInfo{
+			Status:       a.Cache.Status,
+			Stored:       a.Cache.Stored,
+			AgeSeconds:   a.Cache.AgeSeconds,
+			Etag:         a.Cache.ETag,
+			LastModified: a.Cache.LastModified,
+		}
+	}
+	return out
+}
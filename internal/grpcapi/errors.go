@@ -0,0 +1,119 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	pb "github.com/suraif16/webpage-analyzer/internal/grpcapi/analyzerv1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain identifies this service in ErrorInfo details
+const errorDomain = "webpage-analyzer"
+
+// apiError normalizes any error returned by the analyzer into an APIError,
+// the same way the REST handlers do
+func apiError(err error) *domain.APIError {
+	var apiErr *domain.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.ErrTimeout.WithCause(err)
+	default:
+		return domain.ErrInternalServer.WithCause(err)
+	}
+}
+
+// grpcCode maps an error to the gRPC code that best tells clients whether and
+// how to retry. Most follow the HTTP status; failures of the target page that
+// will not go away on retry are FailedPrecondition.
+func grpcCode(e *domain.APIError) codes.Code {
+	switch e.Code {
+	case domain.CodePageTooLarge, domain.CodeRedirectLoop, domain.CodeTooManyRedirects,
+		domain.CodeUpstreamClientError, domain.CodeUnsupportedContent, domain.CodeUnsupportedEncoding:
+		return codes.FailedPrecondition
+	}
+
+	switch e.StatusCode {
+	case 400, 413, 415:
+		return codes.InvalidArgument
+	case 401:
+		return codes.Unauthenticated
+	case 403:
+		return codes.PermissionDenied
+	case 404:
+		return codes.NotFound
+	case 429:
+		return codes.ResourceExhausted
+	case 502, 503:
+		return codes.Unavailable
+	case 504:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// toStatus converts an error into a gRPC status. The details carry the REST
+// error code, invalid fields and the suggested retry delay.
+func toStatus(err error, requestID string) error {
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	e := apiError(err)
+
+	info := &errdetails.ErrorInfo{
+		Reason:   e.Code,
+		Domain:   errorDomain,
+		Metadata: map[string]string{},
+	}
+	if e.Description != "" {
+		info.Metadata["description"] = e.Description
+	}
+	if e.UpstreamStatus != 0 {
+		info.Metadata["upstreamStatus"] = strconv.Itoa(e.UpstreamStatus)
+	}
+	if requestID != "" {
+		info.Metadata["requestId"] = requestID
+	}
+	details := []protoadapt.MessageV1{info}
+	if len(e.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(e.Fields))
+		for i, f := range e.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if e.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)})
+	}
+
+	st := status.New(grpcCode(e), e.Message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// toProtoError converts an error into the message reported for one page of a
+// batch
+func toProtoError(err error) *pb.Error {
+	e := apiError(err)
+	out := &pb.Error{
+		Code:           e.Code,
+		Message:        e.Message,
+		Description:    e.Description,
+		UpstreamStatus: int32(e.UpstreamStatus),
+	}
+	for _, f := range e.Fields {
+		out.Fields = append(out.Fields, &pb.FieldError{Field: f.Field, Rule: f.Rule, Message: f.Message})
+	}
+	return out
+}
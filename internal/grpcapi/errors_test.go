@@ -0,0 +1,67 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCode(t *testing.T) {
+	tests := []struct {
+		err      *domain.APIError
+		expected codes.Code
+	}{
+		{err: domain.ErrInvalidURL, expected: codes.InvalidArgument},
+		{err: domain.ErrRequestTooLarge, expected: codes.InvalidArgument},
+		{err: domain.ErrUnauthorized, expected: codes.Unauthenticated},
+		{err: domain.ErrForbidden, expected: codes.PermissionDenied},
+		{err: domain.ErrRateLimited, expected: codes.ResourceExhausted},
+		{err: domain.ErrQuotaExceeded, expected: codes.ResourceExhausted},
+		{err: domain.ErrTimeout, expected: codes.DeadlineExceeded},
+		{err: domain.ErrPageTooLarge, expected: codes.FailedPrecondition},
+		{err: domain.NewUpstreamError(404, "404 Not Found"), expected: codes.FailedPrecondition},
+		{err: domain.NewUpstreamError(503, "503 Service Unavailable"), expected: codes.Unavailable},
+		{err: domain.ErrInternalServer, expected: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Code, func(t *testing.T) {
+			assert.Equal(t, tt.expected, grpcCode(tt.err))
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	t.Run("retry info", func(t *testing.T) {
+		err := toStatus(domain.ErrRateLimited.WithRetryAfter(3*time.Second), "req-1")
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		var retry *errdetails.RetryInfo
+		for _, d := range st.Details() {
+			if r, ok := d.(*errdetails.RetryInfo); ok {
+				retry = r
+			}
+		}
+		require.NotNil(t, retry)
+		assert.Equal(t, 3*time.Second, retry.GetRetryDelay().AsDuration())
+	})
+
+	t.Run("wrapped deadline", func(t *testing.T) {
+		err := toStatus(fmt.Errorf("fetch: %w", context.DeadlineExceeded), "")
+
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		assert.Equal(t, codes.Canceled, status.Code(toStatus(context.Canceled, "")))
+	})
+}
@@ -0,0 +1,268 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"github.com/suraif16/webpage-analyzer/internal/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// requestIDKey carries the request ID in both directions, like the
+	// X-Request-ID header of the REST API
+	requestIDKey = "x-request-id"
+	// apiKeyKey carries the API key when it is not sent as a bearer token
+	apiKeyKey = "x-api-key"
+)
+
+type callInfoKey struct{}
+
+// callInfo collects what inner interceptors learn about a call for the
+// completion log
+type callInfo struct {
+	key *domain.APIKey
+}
+
+// serverStream overrides the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// unaryLogging is the unary form of streamLogging
+func unaryLogging(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, id := startCall(ctx, logger)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// streamLogging reuses a well-formed x-request-id from the client or generates
+// one, returns it in the response headers, attaches a logger tagged with it to
+// the call context and logs every completed call
+func streamLogging(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := startCall(ss.Context(), logger)
+		_ = ss.SetHeader(metadata.Pairs(requestIDKey, id))
+
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func startCall(ctx context.Context, logger *zap.Logger) (context.Context, string) {
+	id := firstValue(ctx, requestIDKey)
+	if !logging.ValidRequestID(id) {
+		id = uuid.NewString()
+	}
+	fields := []zap.Field{zap.String("request_id", id)}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+	}
+	ctx = logging.WithRequestID(ctx, id)
+	ctx = logging.WithLogger(ctx, logger.With(fields...))
+	ctx = context.WithValue(ctx, callInfoKey{}, &callInfo{})
+	return ctx, id
+}
+
+func logCall(ctx context.Context, logger *zap.Logger, method string, start time.Time, err error) {
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("latency", time.Since(start)),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("ip", p.Addr.String()))
+	}
+	if info, ok := ctx.Value(callInfoKey{}).(*callInfo); ok && info.key != nil {
+		fields = append(fields,
+			zap.String("api_key_id", info.key.ID),
+			zap.String("api_key_name", info.key.Name))
+	}
+	logging.FromContext(ctx, logger).Info("rpc completed", fields...)
+}
+
+// unaryRecover is the unary form of streamRecover
+func unaryRecover(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer recoverCall(ctx, logger, info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+// streamRecover turns a panic in a handler into an Internal error instead of
+// crashing the process
+func streamRecover(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverCall(ss.Context(), logger, info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
+
+func recoverCall(ctx context.Context, logger *zap.Logger, method string, err *error) {
+	if r := recover(); r != nil {
+		logging.FromContext(ctx, logger).Error("panic while serving rpc",
+			zap.String("method", method),
+			zap.Any("panic", r),
+			zap.ByteString("stack", debug.Stack()))
+		*err = toStatus(domain.ErrInternalServer, logging.RequestID(ctx))
+	}
+}
+
+// unaryAuth is the unary form of streamAuth
+func unaryAuth(keys ports.APIKeyManager, failures FailureLimiter, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authenticate(ctx, keys, failures, logger); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamAuth rejects calls without a valid API key in the x-api-key or
+// authorization metadata. The key's rate limit and daily quota are applied by
+// the Server to every page analyzed, so each page of a batch counts. Like the
+// REST API, a peer address that keeps failing authentication is refused with
+// ResourceExhausted before its key is checked.
+func streamAuth(keys ports.APIKeyManager, failures FailureLimiter, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authenticate(ss.Context(), keys, failures, logger); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func authenticate(ctx context.Context, keys ports.APIKeyManager, failures FailureLimiter, logger *zap.Logger) error {
+	client := middleware.ClientID(nil, peerIP(ctx))
+	if failures != nil {
+		if wait := failures.Throttled(client); wait > 0 {
+			logging.FromContext(ctx, logger).Warn("client throttled after failed authentication",
+				zap.String("client", client),
+				zap.Duration("retry_after", wait))
+			return toStatus(domain.ErrRateLimited.WithRetryAfter(wait), logging.RequestID(ctx))
+		}
+	}
+
+	key, err := keys.Authenticate(ctx, apiKeyFromMetadata(ctx))
+	if err == nil {
+		if info, ok := ctx.Value(callInfoKey{}).(*callInfo); ok {
+			info.key = key
+		}
+		return nil
+	}
+	if failures != nil && errors.Is(err, domain.ErrUnauthorized) {
+		failures.Charge(client)
+	}
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) {
+		logging.FromContext(ctx, logger).Error("api key authentication failed", zap.Error(err))
+		apiErr = domain.ErrInternalServer
+	}
+	return toStatus(apiErr, logging.RequestID(ctx))
+}
+
+// callKey returns the API key a call was authenticated with, if any
+func callKey(ctx context.Context) *domain.APIKey {
+	if info, ok := ctx.Value(callInfoKey{}).(*callInfo); ok {
+		return info.key
+	}
+	return nil
+}
+
+// unaryRateLimit is the unary form of streamRateLimit
+func unaryRateLimit(limit Limiter, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := rateLimited(ctx, limit, logger); err != nil {
+			return nil, toStatus(err, logging.RequestID(ctx))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamRateLimit takes every call from the bucket of its API key, or of its
+// peer address when it has none, sharing the buckets of the REST API
+func streamRateLimit(limit Limiter, logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rateLimited(ss.Context(), limit, logger); err != nil {
+			return toStatus(err, logging.RequestID(ss.Context()))
+		}
+		return handler(srv, ss)
+	}
+}
+
+// rateLimited takes a request from the caller's bucket and returns
+// ErrRateLimited when it is empty
+func rateLimited(ctx context.Context, limit Limiter, logger *zap.Logger) error {
+	client := callClient(ctx)
+	wait := limit.Take(client)
+	if wait <= 0 {
+		return nil
+	}
+	logging.FromContext(ctx, logger).Warn("call rate limited",
+		zap.String("client", client),
+		zap.Duration("retry_after", wait))
+	return domain.ErrRateLimited.WithRetryAfter(wait)
+}
+
+// callClient names the rate limit bucket of a call like the REST API does
+func callClient(ctx context.Context) string {
+	return middleware.ClientID(callKey(ctx), peerIP(ctx))
+}
+
+// peerIP returns the address a call came from without its port
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return ip
+}
+
+// apiKeyFromMetadata reads the key from x-api-key or a bearer token
+func apiKeyFromMetadata(ctx context.Context) string {
+	if key := firstValue(ctx, apiKeyKey); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(firstValue(ctx, "authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+func firstValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,316 @@
+// Package grpcapi serves the analyzer over gRPC. It shares the analyzer,
+// validation rules, authentication and error codes with the REST API so both
+// behave the same.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	pb "github.com/suraif16/webpage-analyzer/internal/grpcapi/analyzerv1"
+	"github.com/suraif16/webpage-analyzer/internal/handlers"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// maxRecvMsgSize leaves room for an AnalyzeHTML request at the size limit of
+// the REST API
+const maxRecvMsgSize = handlers.MaxHTMLSize + 1<<20
+
+// BatchPolicy limits AnalyzeBatch and AnalyzeStream calls
+type BatchPolicy struct {
+	// MaxSize is the largest number of pages in one call
+	MaxSize int
+	// Concurrency is the number of pages of one call analyzed at once
+	Concurrency int
+}
+
+// Limiter takes one request from the budget of a client, as named by
+// middleware.ClientID, and returns how long the client has to wait when none
+// is left
+type Limiter interface {
+	Take(client string) time.Duration
+}
+
+// FailureLimiter throttles clients, as named by middleware.ClientID, that keep
+// failing authentication
+type FailureLimiter interface {
+	// Throttled returns how long the client has to wait before trying again
+	Throttled(client string) time.Duration
+	// Charge counts a failed attempt against the client
+	Charge(client string)
+}
+
+// Options configures the gRPC server
+type Options struct {
+	// Keys authenticates calls; nil disables authentication
+	Keys ports.APIKeyManager
+	// AuthFailureLimit throttles failed authentication by peer address; nil
+	// disables it
+	AuthFailureLimit FailureLimiter
+	// RateLimit is taken once per call and AnalyzeRateLimit once per analyzed
+	// page, so that every page of a batch counts; nil disables them
+	RateLimit        Limiter
+	AnalyzeRateLimit Limiter
+	Batch            BatchPolicy
+	Reflection       bool
+}
+
+// NewGRPCServer creates a gRPC server exposing the AnalyzerService with
+// tracing, request IDs, logging, panic recovery and, when configured, API key
+// authentication and rate limits
+func NewGRPCServer(analyzer ports.PageAnalyzer, opts Options, logger *zap.Logger) *grpc.Server {
+	unary := []grpc.UnaryServerInterceptor{unaryLogging(logger), unaryRecover(logger)}
+	stream := []grpc.StreamServerInterceptor{streamLogging(logger), streamRecover(logger)}
+	if opts.Keys != nil {
+		unary = append(unary, unaryAuth(opts.Keys, opts.AuthFailureLimit, logger))
+		stream = append(stream, streamAuth(opts.Keys, opts.AuthFailureLimit, logger))
+	}
+	// Limits run after authentication so that keys are limited by key
+	if opts.RateLimit != nil {
+		unary = append(unary, unaryRateLimit(opts.RateLimit, logger))
+		stream = append(stream, streamRateLimit(opts.RateLimit, logger))
+	}
+
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.MaxRecvMsgSize(maxRecvMsgSize),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	pb.RegisterAnalyzerServiceServer(server, NewServer(analyzer, opts, logger))
+	if opts.Reflection {
+		reflection.Register(server)
+	}
+	return server
+}
+
+// Server implements the AnalyzerService
+type Server struct {
+	pb.UnimplementedAnalyzerServiceServer
+	analyzer     ports.PageAnalyzer
+	keys         ports.APIKeyManager
+	batch        BatchPolicy
+	analyzeLimit Limiter
+	logger       *zap.Logger
+}
+
+// NewServer creates an AnalyzerService implementation. Every page analyzed
+// counts against opts.AnalyzeRateLimit and the quota of the caller's key.
+func NewServer(analyzer ports.PageAnalyzer, opts Options, logger *zap.Logger) *Server {
+	batch := opts.Batch
+	if batch.Concurrency < 1 {
+		batch.Concurrency = 1
+	}
+	return &Server{analyzer: analyzer, keys: opts.Keys, batch: batch, analyzeLimit: opts.AnalyzeRateLimit, logger: logger}
+}
+
+// Analyze fetches and analyzes one page
+func (s *Server) Analyze(ctx context.Context, req *pb.AnalyzeRequest) (*pb.PageAnalysis, error) {
+	analysis, err := s.analyze(ctx, req)
+	if err != nil {
+		return nil, s.fail(ctx, "analysis failed", err)
+	}
+	return toProtoAnalysis(analysis), nil
+}
+
+// AnalyzeHTML analyzes HTML supplied by the caller
+func (s *Server) AnalyzeHTML(ctx context.Context, req *pb.AnalyzeHTMLRequest) (*pb.PageAnalysis, error) {
+	if len(req.GetHtml()) > handlers.MaxHTMLSize {
+		return nil, s.fail(ctx, "html too large", domain.ErrRequestTooLarge)
+	}
+	r := domain.HTMLAnalysisRequest{HTML: req.GetHtml(), BaseURL: req.GetBaseUrl()}
+	if apiErr := handlers.ValidateRequest(&r); apiErr != nil {
+		return nil, s.fail(ctx, "invalid request", apiErr)
+	}
+	if err := s.admit(ctx); err != nil {
+		return nil, s.fail(ctx, "analysis not admitted", err)
+	}
+
+	analysis, err := s.analyzer.AnalyzeHTML(ctx, r)
+	if err != nil {
+		return nil, s.fail(ctx, "html analysis failed", err)
+	}
+	return toProtoAnalysis(analysis), nil
+}
+
+// AnalyzeBatch analyzes several pages and returns all results at once
+func (s *Server) AnalyzeBatch(ctx context.Context, req *pb.AnalyzeBatchRequest) (*pb.AnalyzeBatchResponse, error) {
+	if err := s.checkBatch(req); err != nil {
+		return nil, s.fail(ctx, "invalid batch", err)
+	}
+
+	results := make([]*pb.BatchResult, len(req.GetRequests()))
+	s.runBatch(ctx, req.GetRequests(), nil, func(i int, analysis *domain.PageAnalysis, err error) {
+		results[i] = batchResult(i, req.GetRequests()[i], analysis, err)
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, s.fail(ctx, "batch cancelled", err)
+	}
+	return &pb.AnalyzeBatchResponse{Results: results}, nil
+}
+
+// AnalyzeStream analyzes several pages and reports progress as it goes
+func (s *Server) AnalyzeStream(req *pb.AnalyzeBatchRequest, stream pb.AnalyzerService_AnalyzeStreamServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	if err := s.checkBatch(req); err != nil {
+		return s.fail(ctx, "invalid batch", err)
+	}
+
+	reqs := req.GetRequests()
+	total := int32(len(reqs))
+	var completed int32
+	var sendErr error
+	send := func(p *pb.AnalysisProgress) {
+		if sendErr != nil {
+			return
+		}
+		p.Total = total
+		p.Completed = completed
+		if sendErr = stream.Send(p); sendErr != nil {
+			cancel()
+		}
+	}
+
+	s.runBatch(ctx, reqs,
+		func(i int) {
+			send(&pb.AnalysisProgress{Index: int32(i), Url: reqs[i].GetUrl(), Stage: pb.ProgressStage_PROGRESS_STAGE_STARTED})
+		},
+		func(i int, analysis *domain.PageAnalysis, err error) {
+			completed++
+			p := &pb.AnalysisProgress{Index: int32(i), Url: reqs[i].GetUrl()}
+			if err != nil {
+				p.Stage = pb.ProgressStage_PROGRESS_STAGE_FAILED
+				p.Error = toProtoError(err)
+			} else {
+				p.Stage = pb.ProgressStage_PROGRESS_STAGE_COMPLETED
+				p.Analysis = toProtoAnalysis(analysis)
+			}
+			send(p)
+		})
+
+	if sendErr != nil {
+		return sendErr
+	}
+	if err := stream.Context().Err(); err != nil {
+		return s.fail(ctx, "stream cancelled", err)
+	}
+	return nil
+}
+
+func (s *Server) analyze(ctx context.Context, req *pb.AnalyzeRequest) (*domain.PageAnalysis, error) {
+	r := fromProtoRequest(req)
+	if apiErr := handlers.ValidateRequest(&r); apiErr != nil {
+		return nil, apiErr
+	}
+	if err := s.admit(ctx); err != nil {
+		return nil, err
+	}
+	return s.analyzer.Analyze(ctx, r)
+}
+
+// admit counts one valid page against the analyze rate limit and against the
+// rate limit and daily quota of the caller's API key. A page of a batch over
+// a limit fails on its own like any other failed page.
+func (s *Server) admit(ctx context.Context) error {
+	if s.analyzeLimit != nil {
+		if err := rateLimited(ctx, s.analyzeLimit, s.logger); err != nil {
+			return err
+		}
+	}
+	key := callKey(ctx)
+	if s.keys == nil || key == nil {
+		return nil
+	}
+	err := s.keys.Allow(ctx, *key)
+	var apiErr *domain.APIError
+	if err != nil && !errors.As(err, &apiErr) {
+		logging.FromContext(ctx, s.logger).Error("api key limit check failed", zap.Error(err))
+		return domain.ErrInternalServer
+	}
+	return err
+}
+
+// checkBatch rejects empty batches and batches over the size limit
+func (s *Server) checkBatch(req *pb.AnalyzeBatchRequest) error {
+	n := len(req.GetRequests())
+	switch {
+	case n == 0:
+		return domain.ErrValidationFailed.WithFields([]domain.FieldError{
+			{Field: "requests", Rule: "min", Message: "must be at least 1"},
+		})
+	case s.batch.MaxSize > 0 && n > s.batch.MaxSize:
+		return domain.ErrValidationFailed.WithFields([]domain.FieldError{
+			{Field: "requests", Rule: "max", Message: fmt.Sprintf("must be at most %d", s.batch.MaxSize)},
+		})
+	}
+	return nil
+}
+
+// runBatch analyzes the requests with bounded concurrency. started and done
+// are called one at a time; once ctx is done, requests not yet started are
+// reported as failed with the context error.
+func (s *Server) runBatch(ctx context.Context, reqs []*pb.AnalyzeRequest, started func(int), done func(int, *domain.PageAnalysis, error)) {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, s.batch.Concurrency)
+	)
+	report := func(fn func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		fn()
+	}
+
+	for i, req := range reqs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			report(func() { done(i, nil, ctx.Err()) })
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if started != nil {
+				report(func() { started(i) })
+			}
+			analysis, err := s.analyze(ctx, req)
+			if err != nil {
+				logging.FromContext(ctx, s.logger).Warn("batch page failed",
+					zap.Int("index", i), zap.String("url", req.GetUrl()), zap.Error(err))
+			}
+			report(func() { done(i, analysis, err) })
+		}()
+	}
+	wg.Wait()
+}
+
+func batchResult(i int, req *pb.AnalyzeRequest, analysis *domain.PageAnalysis, err error) *pb.BatchResult {
+	result := &pb.BatchResult{Index: int32(i), Url: req.GetUrl()}
+	if err != nil {
+		result.Outcome = &pb.BatchResult_Error{Error: toProtoError(err)}
+	} else {
+		result.Outcome = &pb.BatchResult_Analysis{Analysis: toProtoAnalysis(analysis)}
+	}
+	return result
+}
+
+// fail logs a failed call and converts the error into a gRPC status
+func (s *Server) fail(ctx context.Context, msg string, err error) error {
+	logging.FromContext(ctx, s.logger).Warn(msg, zap.Error(err))
+	return toStatus(err, logging.RequestID(ctx))
+}
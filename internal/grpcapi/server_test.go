@@ -0,0 +1,431 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	pb "github.com/suraif16/webpage-analyzer/internal/grpcapi/analyzerv1"
+	"github.com/suraif16/webpage-analyzer/internal/middleware"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// stubAnalyzer answers with a fixed analysis, or the error registered for a URL
+type stubAnalyzer struct {
+	errs map[string]error
+}
+
+func (s stubAnalyzer) Analyze(ctx context.Context, req domain.AnalysisRequest) (*domain.PageAnalysis, error) {
+	if err := s.errs[req.URL]; err != nil {
+		return nil, err
+	}
+	return &domain.PageAnalysis{
+		HTMLVersion: "HTML5",
+		PageTitle:   "Title of " + req.URL,
+		Headings:    domain.HeadingCount{H1: 1},
+		Redirects:   domain.RedirectAnalysis{FinalURL: req.URL},
+	}, nil
+}
+
+func (s stubAnalyzer) AnalyzeHTML(ctx context.Context, req domain.HTMLAnalysisRequest) (*domain.PageAnalysis, error) {
+	return &domain.PageAnalysis{HTMLVersion: "HTML5", PageTitle: "submitted"}, nil
+}
+
+// stubKeyManager authenticates a fixed set of keys and, when used is set,
+// counts usage and fails Allow once quota requests have been allowed
+type stubKeyManager struct {
+	ports.APIKeyManager
+	keys  map[string]*domain.APIKey
+	used  *atomic.Int64
+	quota int64
+}
+
+func (s stubKeyManager) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	key, ok := s.keys[rawKey]
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+	return key, nil
+}
+
+func (s stubKeyManager) Allow(ctx context.Context, key domain.APIKey) error {
+	if s.used == nil {
+		return nil
+	}
+	if s.quota > 0 && s.used.Load() >= s.quota {
+		return domain.ErrQuotaExceeded.WithRetryAfter(time.Hour)
+	}
+	s.used.Add(1)
+	return nil
+}
+
+func newTestClient(t *testing.T, analyzer ports.PageAnalyzer, opts Options) pb.AnalyzerServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := NewGRPCServer(analyzer, opts, zap.NewNop())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewAnalyzerServiceClient(conn)
+}
+
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	t.Helper()
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("no ErrorInfo in %v", err)
+	return nil
+}
+
+func fieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			return br.GetFieldViolations()
+		}
+	}
+	return nil
+}
+
+func TestServer_Analyze(t *testing.T) {
+	analyzer := stubAnalyzer{errs: map[string]error{
+		"https://missing.example.com": domain.NewUpstreamError(404, "404 Not Found"),
+	}}
+	client := newTestClient(t, analyzer, Options{Batch: BatchPolicy{MaxSize: 5, Concurrency: 2}})
+
+	t.Run("success", func(t *testing.T) {
+		var header metadata.MD
+		ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDKey, "req-1")
+
+		resp, err := client.Analyze(ctx, &pb.AnalyzeRequest{Url: "https://example.com"}, grpc.Header(&header))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Title of https://example.com", resp.GetPageTitle())
+		assert.Equal(t, int32(1), resp.GetHeadings().GetH1())
+		assert.Equal(t, []string{"req-1"}, header.Get(requestIDKey))
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := client.Analyze(context.Background(), &pb.AnalyzeRequest{Url: "not-a-url"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		info := errorInfo(t, err)
+		assert.Equal(t, domain.CodeInvalidURL, info.GetReason())
+		assert.NotEmpty(t, info.GetMetadata()["requestId"])
+
+		violations := fieldViolations(err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "url", violations[0].GetField())
+	})
+
+	t.Run("upstream error", func(t *testing.T) {
+		_, err := client.Analyze(context.Background(), &pb.AnalyzeRequest{Url: "https://missing.example.com"})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		info := errorInfo(t, err)
+		assert.Equal(t, "404", info.GetMetadata()["upstreamStatus"])
+	})
+
+	t.Run("html", func(t *testing.T) {
+		resp, err := client.AnalyzeHTML(context.Background(), &pb.AnalyzeHTMLRequest{Html: "<html></html>"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "submitted", resp.GetPageTitle())
+	})
+
+	t.Run("html base url", func(t *testing.T) {
+		_, err := client.AnalyzeHTML(context.Background(), &pb.AnalyzeHTMLRequest{Html: "<html></html>", BaseUrl: "relative"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_AnalyzeBatch(t *testing.T) {
+	analyzer := stubAnalyzer{errs: map[string]error{"https://slow.example.com": domain.ErrTimeout}}
+	client := newTestClient(t, analyzer, Options{Batch: BatchPolicy{MaxSize: 3, Concurrency: 2}})
+
+	t.Run("mixed results", func(t *testing.T) {
+		resp, err := client.AnalyzeBatch(context.Background(), &pb.AnalyzeBatchRequest{Requests: []*pb.AnalyzeRequest{
+			{Url: "https://a.example.com"},
+			{Url: "https://slow.example.com"},
+			{Url: "https://c.example.com"},
+		}})
+
+		require.NoError(t, err)
+		require.Len(t, resp.GetResults(), 3)
+		for i, r := range resp.GetResults() {
+			assert.Equal(t, int32(i), r.GetIndex())
+		}
+		assert.Equal(t, "Title of https://a.example.com", resp.GetResults()[0].GetAnalysis().GetPageTitle())
+		assert.Equal(t, domain.CodeTimeout, resp.GetResults()[1].GetError().GetCode())
+		assert.Equal(t, "Title of https://c.example.com", resp.GetResults()[2].GetAnalysis().GetPageTitle())
+	})
+
+	tests := []struct {
+		name    string
+		urls    int
+		message string
+	}{
+		{name: "empty", urls: 0, message: "must be at least 1"},
+		{name: "too large", urls: 4, message: "must be at most 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pb.AnalyzeBatchRequest{}
+			for i := 0; i < tt.urls; i++ {
+				req.Requests = append(req.Requests, &pb.AnalyzeRequest{Url: "https://example.com"})
+			}
+
+			_, err := client.AnalyzeBatch(context.Background(), req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, domain.CodeValidationFailed, errorInfo(t, err).GetReason())
+			violations := fieldViolations(err)
+			require.Len(t, violations, 1)
+			assert.Equal(t, "requests", violations[0].GetField())
+			assert.Equal(t, tt.message, violations[0].GetDescription())
+		})
+	}
+}
+
+func TestServer_AnalyzeStream(t *testing.T) {
+	analyzer := stubAnalyzer{errs: map[string]error{"https://b.example.com": domain.ErrTimeout}}
+	client := newTestClient(t, analyzer, Options{Batch: BatchPolicy{MaxSize: 5, Concurrency: 2}})
+
+	stream, err := client.AnalyzeStream(context.Background(), &pb.AnalyzeBatchRequest{Requests: []*pb.AnalyzeRequest{
+		{Url: "https://a.example.com"},
+		{Url: "https://b.example.com"},
+		{Url: "not-a-url"},
+	}})
+	require.NoError(t, err)
+
+	var events []string
+	var last *pb.AnalysisProgress
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), p.GetTotal())
+		if p.GetStage() != pb.ProgressStage_PROGRESS_STAGE_STARTED {
+			last = p
+		}
+		events = append(events, p.GetUrl()+" "+strings.TrimPrefix(p.GetStage().String(), "PROGRESS_STAGE_"))
+	}
+	sort.Strings(events)
+
+	assert.Equal(t, []string{
+		"https://a.example.com COMPLETED",
+		"https://a.example.com STARTED",
+		"https://b.example.com FAILED",
+		"https://b.example.com STARTED",
+		"not-a-url FAILED",
+		"not-a-url STARTED",
+	}, events)
+	require.NotNil(t, last)
+	assert.Equal(t, int32(3), last.GetCompleted())
+}
+
+func TestServer_Auth(t *testing.T) {
+	keys := stubKeyManager{keys: map[string]*domain.APIKey{"client-key": {ID: "k1", Name: "ci", Role: domain.RoleClient}}}
+	client := newTestClient(t, stubAnalyzer{}, Options{Keys: keys, Batch: BatchPolicy{MaxSize: 5}})
+	req := &pb.AnalyzeRequest{Url: "https://example.com"}
+
+	tests := []struct {
+		name     string
+		md       []string
+		expected codes.Code
+	}{
+		{name: "missing key", expected: codes.Unauthenticated},
+		{name: "unknown key", md: []string{"x-api-key", "other"}, expected: codes.Unauthenticated},
+		{name: "api key", md: []string{"x-api-key", "client-key"}, expected: codes.OK},
+		{name: "bearer token", md: []string{"authorization", "Bearer client-key"}, expected: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+
+			_, err := client.Analyze(ctx, req)
+			assert.Equal(t, tt.expected, status.Code(err))
+
+			stream, err := client.AnalyzeStream(ctx, &pb.AnalyzeBatchRequest{Requests: []*pb.AnalyzeRequest{req}})
+			require.NoError(t, err)
+			for err == nil {
+				_, err = stream.Recv()
+			}
+			if tt.expected == codes.OK {
+				assert.Equal(t, io.EOF, err)
+			} else {
+				assert.Equal(t, tt.expected, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestServer_AuthCountsAnalyzedPages(t *testing.T) {
+	keys := stubKeyManager{
+		keys:  map[string]*domain.APIKey{"client-key": {ID: "k1", Name: "ci", Role: domain.RoleClient}},
+		used:  new(atomic.Int64),
+		quota: 5,
+	}
+	client := newTestClient(t, stubAnalyzer{}, Options{Keys: keys, Batch: BatchPolicy{MaxSize: 5}})
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "client-key")
+
+	_, err := client.Analyze(ctx, &pb.AnalyzeRequest{Url: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), keys.used.Load())
+
+	// Rejected calls do not use up the quota
+	_, err = client.Analyze(ctx, &pb.AnalyzeRequest{Url: "not a url"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, int64(1), keys.used.Load())
+
+	// Every valid page of a batch counts
+	stream, err := client.AnalyzeStream(ctx, &pb.AnalyzeBatchRequest{Requests: []*pb.AnalyzeRequest{
+		{Url: "https://example.com/a"}, {Url: "https://example.com/b"}, {Url: "not a url"},
+	}})
+	require.NoError(t, err)
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, int64(3), keys.used.Load())
+
+	// Pages over the quota fail on their own
+	resp, err := client.AnalyzeBatch(ctx, &pb.AnalyzeBatchRequest{Requests: []*pb.AnalyzeRequest{
+		{Url: "https://example.com/a"}, {Url: "https://example.com/b"}, {Url: "https://example.com/c"},
+	}})
+	require.NoError(t, err)
+	var exceeded int
+	for _, r := range resp.GetResults() {
+		if r.GetError().GetCode() == domain.CodeQuotaExceeded {
+			exceeded++
+		}
+	}
+	assert.Equal(t, 1, exceeded)
+	assert.Equal(t, int64(5), keys.used.Load())
+
+	_, err = client.Analyze(ctx, &pb.AnalyzeRequest{Url: "https://example.com"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestServer_AuthFailureLimit(t *testing.T) {
+	keys := stubKeyManager{keys: map[string]*domain.APIKey{"client-key": {ID: "k1", Role: domain.RoleClient}}}
+	failures := middleware.NewRateLimiter(middleware.RateLimitPolicy{RequestsPerMinute: 1, Burst: 2}, zap.NewNop())
+	client := newTestClient(t, stubAnalyzer{}, Options{Keys: keys, AuthFailureLimit: failures})
+	valid := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "client-key")
+	wrong := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "guess")
+
+	// Successful calls are not counted
+	for i := 0; i < 3; i++ {
+		_, err := client.Analyze(valid, &pb.AnalyzeRequest{Url: "https://example.com"})
+		require.NoError(t, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Analyze(wrong, &pb.AnalyzeRequest{Url: "https://example.com"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+
+	// Once throttled, the peer is refused before its key is checked
+	_, err := client.Analyze(valid, &pb.AnalyzeRequest{Url: "https://example.com"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, domain.CodeRateLimited, errorInfo(t, err).GetReason())
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	keys := stubKeyManager{keys: map[string]*domain.APIKey{
+		"first":  {ID: "k1", Role: domain.RoleClient},
+		"second": {ID: "k2", Role: domain.RoleClient},
+	}}
+	limit := middleware.NewRateLimiter(middleware.RateLimitPolicy{RequestsPerMinute: 1, Burst: 2}, zap.NewNop())
+	analyzeLimit := middleware.NewRateLimiter(middleware.RateLimitPolicy{RequestsPerMinute: 1, Burst: 3}, zap.NewNop())
+	client := newTestClient(t, stubAnalyzer{}, Options{
+		Keys:             keys,
+		RateLimit:        limit,
+		AnalyzeRateLimit: analyzeLimit,
+		Batch:            BatchPolicy{MaxSize: 5, Concurrency: 2},
+	})
+	first := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "first")
+
+	_, err := client.Analyze(first, &pb.AnalyzeRequest{Url: "https://example.com"})
+	require.NoError(t, err)
+
+	// Every page of a batch counts against the analyze limit
+	resp, err := client.AnalyzeBatch(first, &pb.AnalyzeBatchRequest{Requests: []*pb.AnalyzeRequest{
+		{Url: "https://a.example.com"}, {Url: "https://b.example.com"}, {Url: "https://c.example.com"},
+	}})
+	require.NoError(t, err)
+	var limited int
+	for _, r := range resp.GetResults() {
+		if r.GetError().GetCode() == domain.CodeRateLimited {
+			limited++
+		}
+	}
+	assert.Equal(t, 1, limited)
+
+	// The call limit is spent by the two calls above
+	_, err = client.Analyze(first, &pb.AnalyzeRequest{Url: "https://example.com"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, domain.CodeRateLimited, errorInfo(t, err).GetReason())
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if r, ok := d.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if assert.NotNil(t, retry) {
+		assert.Positive(t, retry.GetRetryDelay().AsDuration())
+	}
+
+	// Keys are limited separately, sharing their buckets with the REST API
+	second := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "second")
+	_, err = client.Analyze(second, &pb.AnalyzeRequest{Url: "https://example.com"})
+	assert.NoError(t, err)
+	assert.Zero(t, limit.Take(middleware.ClientID(keys.keys["second"], "")))
+	assert.Positive(t, limit.Take(middleware.ClientID(keys.keys["second"], "")))
+}
+
+func TestServer_Recover(t *testing.T) {
+	client := newTestClient(t, panickingAnalyzer{}, Options{})
+
+	_, err := client.Analyze(context.Background(), &pb.AnalyzeRequest{Url: "https://example.com"})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, domain.CodeInternalError, errorInfo(t, err).GetReason())
+}
+
+type panickingAnalyzer struct {
+	stubAnalyzer
+}
+
+func (panickingAnalyzer) Analyze(ctx context.Context, req domain.AnalysisRequest) (*domain.PageAnalysis, error) {
+	panic("boom")
+}
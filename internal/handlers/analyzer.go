@@ -1,20 +1,31 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/suraif16/webpage-analyzer/internal/core/domain"
 	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	"github.com/suraif16/webpage-analyzer/internal/httperr"
 	"go.uber.org/zap"
+	"io"
 	"net/http"
 	"time"
 )
 
+// MaxHTMLSize limits the size of submitted documents
+const MaxHTMLSize = 10 << 20
+
 // @title Web Page Analyzer API
 // @version 1.0
 // @description API for analyzing web pages
 
 // @host localhost:8080
 // @BasePath /
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 type AnalyzerHandler struct {
 	analyzer ports.PageAnalyzer
 	logger   *zap.Logger
@@ -29,42 +40,55 @@ func NewAnalyzerHandler(analyzer ports.PageAnalyzer, logger *zap.Logger) *Analyz
 
 // Analyze godoc
 // @Summary Analyze a webpage
-// @Description Analyzes a webpage for HTML version, headings, links, and login form
+// @Description Analyzes a webpage for HTML version, headings, links, login form and the redirect chain followed to reach it.
+// @Description Errors are returned as application/problem+json when the Accept header asks for it.
 // @Tags analyzer
 // @Accept json
-// @Produce json
-// @Param request body domain.AnalysisRequest true "URL to analyze"
+// @Produce json,application/problem+json
+// @Security ApiKeyAuth
+// @Param request body domain.AnalysisRequest true "URL to analyze and optional fetch settings"
 // @Success 200 {object} domain.PageAnalysis
 // @Failure 400 {object} domain.APIError
+// @Failure 401 {object} domain.APIError
 // @Failure 404 {object} domain.APIError
+// @Failure 429 {object} domain.APIError
 // @Failure 500 {object} domain.APIError
+// @Failure 502 {object} domain.APIError
+// @Failure 503 {object} domain.APIError
+// @Failure 504 {object} domain.APIError
 // @Router /analyze [post]
 func (h *AnalyzerHandler) Analyze(c *gin.Context) {
 	startTime := time.Now()
+	logger := requestLogger(c, h.logger)
 
 	var req domain.AnalysisRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error("invalid request body", zap.Error(err))
-		c.JSON(http.StatusBadRequest, domain.ErrInvalidURL)
+		logger.Error("invalid request body", zap.Error(err))
+		httperr.Write(c, bindingError(err))
 		return
 	}
 
-	h.logger.Info("analyzing url", zap.String("url", req.URL))
+	logger.Info("analyzing url",
+		zap.String("url", req.URL),
+		zap.Any("options", req.FetchOptions.Redacted()))
 
-	analysis, err := h.analyzer.Analyze(c.Request.Context(), req.URL)
+	analysis, err := h.analyzer.Analyze(c.Request.Context(), req)
 	if err != nil {
-		if apiErr, ok := err.(*domain.APIError); ok {
-			h.logger.Error("analysis failed",
+		var apiErr *domain.APIError
+		if errors.As(err, &apiErr) {
+			logger.Error("analysis failed",
 				zap.String("url", req.URL),
+				zap.String("code", apiErr.Code),
 				zap.Error(apiErr))
-			c.JSON(apiErr.StatusCode, apiErr)
+			httperr.Write(c, apiErr)
 			return
 		}
-		c.JSON(http.StatusInternalServerError, domain.ErrInternalServer)
+		logger.Error("analysis failed", zap.String("url", req.URL), zap.Error(err))
+		httperr.Write(c, domain.ErrInternalServer)
 		return
 	}
 
-	h.logger.Info("analysis completed successfully",
+	logger.Info("analysis completed successfully",
 		zap.String("url", req.URL),
 		zap.String("title", analysis.PageTitle),
 		zap.String("html_version", analysis.HTMLVersion),
@@ -78,7 +102,117 @@ func (h *AnalyzerHandler) Analyze(c *gin.Context) {
 		zap.Int("external_links", analysis.Links.External),
 		zap.Int("inaccessible_links", analysis.Links.Inaccessible),
 		zap.Bool("has_login_form", analysis.HasLoginForm),
+		zap.Int("redirects", len(analysis.Redirects.Chain)),
+		zap.String("final_url", analysis.Redirects.FinalURL),
+		zap.Float64("fetch_ttfb_ms", analysis.Performance.TimeToFirstByteMs),
+		zap.Float64("fetch_total_ms", analysis.Performance.TotalMs),
 		zap.Duration("duration", time.Since(startTime)),
 	)
 	c.JSON(http.StatusOK, analysis)
 }
+
+// AnalyzeHTML godoc
+// @Summary Analyze submitted HTML
+// @Description Runs the analysis over HTML supplied in the request instead of fetching a URL.
+// @Description Accepts a JSON body, a raw text/html body (base URL in the baseUrl query parameter)
+// @Description or a multipart upload with a "file" or "html" field and an optional "baseUrl" field.
+// @Tags analyzer
+// @Accept json,html,mpfd
+// @Produce json,application/problem+json
+// @Security ApiKeyAuth
+// @Param request body domain.HTMLAnalysisRequest false "HTML to analyze when sending JSON"
+// @Param baseUrl query string false "Base URL used to resolve relative links for text/html bodies"
+// @Param file formData file false "HTML file to analyze"
+// @Success 200 {object} domain.PageAnalysis
+// @Failure 400 {object} domain.APIError
+// @Failure 401 {object} domain.APIError
+// @Failure 413 {object} domain.APIError
+// @Failure 415 {object} domain.APIError
+// @Failure 429 {object} domain.APIError
+// @Failure 500 {object} domain.APIError
+// @Router /analyze/html [post]
+func (h *AnalyzerHandler) AnalyzeHTML(c *gin.Context) {
+	startTime := time.Now()
+	logger := requestLogger(c, h.logger)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxHTMLSize)
+
+	req, err := bindHTMLRequest(c)
+	if err != nil {
+		logger.Error("invalid request body",
+			zap.String("content_type", c.ContentType()),
+			zap.Error(err))
+		httperr.Write(c, bindingError(err))
+		return
+	}
+
+	logger.Info("analyzing submitted html",
+		zap.String("base_url", req.BaseURL),
+		zap.Int("size", len(req.HTML)))
+
+	analysis, err := h.analyzer.AnalyzeHTML(c.Request.Context(), req)
+	if err != nil {
+		var apiErr *domain.APIError
+		if errors.As(err, &apiErr) {
+			logger.Error("html analysis failed",
+				zap.String("code", apiErr.Code),
+				zap.Error(apiErr))
+			httperr.Write(c, apiErr)
+			return
+		}
+		logger.Error("html analysis failed", zap.Error(err))
+		httperr.Write(c, domain.ErrInternalServer)
+		return
+	}
+
+	logger.Info("html analysis completed successfully",
+		zap.String("title", analysis.PageTitle),
+		zap.String("html_version", analysis.HTMLVersion),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	c.JSON(http.StatusOK, analysis)
+}
+
+// bindHTMLRequest reads the HTML and base URL from a JSON, text/html or
+// multipart request and validates the result
+func bindHTMLRequest(c *gin.Context) (domain.HTMLAnalysisRequest, error) {
+	var req domain.HTMLAnalysisRequest
+
+	switch c.ContentType() {
+	case binding.MIMEJSON:
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return req, err
+		}
+		return req, nil
+	case binding.MIMEHTML:
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return req, err
+		}
+		req.HTML = string(body)
+		req.BaseURL = c.Query("baseUrl")
+	case binding.MIMEMultipartPOSTForm:
+		if err := c.Request.ParseMultipartForm(MaxHTMLSize); err != nil {
+			return req, err
+		}
+		req.HTML = c.PostForm("html")
+		req.BaseURL = c.PostForm("baseUrl")
+		if file, err := c.FormFile("file"); err == nil {
+			f, err := file.Open()
+			if err != nil {
+				return req, err
+			}
+			defer f.Close()
+			body, err := io.ReadAll(f)
+			if err != nil {
+				return req, err
+			}
+			req.HTML = string(body)
+		} else if !errors.Is(err, http.ErrMissingFile) {
+			return req, err
+		}
+	default:
+		return req, domain.ErrUnsupportedMediaType
+	}
+
+	return req, binding.Validator.ValidateStruct(&req)
+}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -20,8 +23,16 @@ type MockAnalyzer struct {
 	mock.Mock
 }
 
-func (m *MockAnalyzer) Analyze(ctx context.Context, url string) (*domain.PageAnalysis, error) {
-	args := m.Called(ctx, url)
+func (m *MockAnalyzer) Analyze(ctx context.Context, req domain.AnalysisRequest) (*domain.PageAnalysis, error) {
+	args := m.Called(ctx, req.URL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PageAnalysis), args.Error(1)
+}
+
+func (m *MockAnalyzer) AnalyzeHTML(ctx context.Context, req domain.HTMLAnalysisRequest) (*domain.PageAnalysis, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -116,6 +127,30 @@ func TestAnalyzerHandler_Analyze(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   domain.ErrPageNotFound,
 		},
+		{
+			name: "Wrapped DNS failure",
+			requestBody: domain.AnalysisRequest{
+				URL: "https://unknown.example",
+			},
+			setupMock: func(ma *MockAnalyzer) {
+				ma.On("Analyze", mock.Anything, "https://unknown.example").
+					Return(nil, fmt.Errorf("analyze: %w", domain.ErrDNSResolutionFailed))
+			},
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   domain.ErrDNSResolutionFailed,
+		},
+		{
+			name: "Unexpected error",
+			requestBody: domain.AnalysisRequest{
+				URL: "https://example.com",
+			},
+			setupMock: func(ma *MockAnalyzer) {
+				ma.On("Analyze", mock.Anything, "https://example.com").
+					Return(nil, errors.New("boom"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   domain.ErrInternalServer,
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,3 +183,279 @@ func TestAnalyzerHandler_Analyze(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzerHandler_ProblemResponses(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		body             string
+		setupMock        func(*MockAnalyzer)
+		expectedStatus   int
+		expectedCode     string
+		expectedType     string
+		expectedFields   []domain.FieldError
+		expectedUpstream int
+	}{
+		{
+			name:           "Invalid URL reports field error",
+			body:           `{"url":"invalid-url"}`,
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   domain.CodeInvalidURL,
+			expectedType:   domain.ProblemTypeBase + "invalid-url",
+			expectedFields: []domain.FieldError{
+				{Field: "url", Rule: "url", Message: "must be a valid absolute URL"},
+			},
+		},
+		{
+			name:           "Out of range option",
+			body:           `{"url":"https://example.com","maxRedirects":50}`,
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   domain.CodeValidationFailed,
+			expectedType:   domain.ProblemTypeBase + "validation-failed",
+			expectedFields: []domain.FieldError{
+				{Field: "maxRedirects", Rule: "max", Message: "must be at most 20"},
+			},
+		},
+		{
+			name:           "Basic auth without username",
+			body:           `{"url":"https://example.com","auth":{"type":"basic","password":"secret"}}`,
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   domain.CodeValidationFailed,
+			expectedType:   domain.ProblemTypeBase + "validation-failed",
+			expectedFields: []domain.FieldError{
+				{Field: "username", Rule: "required_if", Message: "is required"},
+			},
+		},
+		{
+			name:           "Malformed JSON",
+			body:           `{"url":`,
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   domain.CodeInvalidRequestBody,
+			expectedType:   domain.ProblemTypeBase + "invalid-request-body",
+		},
+		{
+			name:           "Wrong field type",
+			body:           `{"url":"https://example.com","maxRedirects":"three"}`,
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   domain.CodeInvalidRequestBody,
+			expectedType:   domain.ProblemTypeBase + "invalid-request-body",
+			expectedFields: []domain.FieldError{
+				{Field: "maxRedirects", Rule: "type", Message: "must be of type int"},
+			},
+		},
+		{
+			name: "Upstream error",
+			body: `{"url":"https://example.com"}`,
+			setupMock: func(ma *MockAnalyzer) {
+				ma.On("Analyze", mock.Anything, "https://example.com").
+					Return(nil, domain.NewUpstreamError(503, "503 Service Unavailable"))
+			},
+			expectedStatus:   http.StatusBadGateway,
+			expectedCode:     domain.CodeUpstreamServerError,
+			expectedType:     domain.ProblemTypeBase + "upstream-server-error",
+			expectedUpstream: 503,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAnalyzer := new(MockAnalyzer)
+			tt.setupMock(mockAnalyzer)
+			handler := NewAnalyzerHandler(mockAnalyzer, logger)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/analyze", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set("Accept", "application/problem+json, application/json;q=0.5")
+
+			handler.Analyze(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+			var problem domain.Problem
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+			assert.Equal(t, tt.expectedCode, problem.Code)
+			assert.Equal(t, tt.expectedType, problem.Type)
+			assert.Equal(t, "/analyze", problem.Instance)
+			assert.NotEmpty(t, problem.Title)
+			assert.Equal(t, tt.expectedFields, problem.Errors)
+			assert.Equal(t, tt.expectedUpstream, problem.UpstreamStatus)
+
+			mockAnalyzer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnalyzerHandler_AnalyzeHTML(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	gin.SetMode(gin.TestMode)
+
+	const html = "<html><head><title>Preview</title></head></html>"
+	analysis := &domain.PageAnalysis{HTMLVersion: "Unknown", PageTitle: "Preview"}
+
+	multipartBody := func(fields map[string]string, file string) (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		for name, value := range fields {
+			writer.WriteField(name, value)
+		}
+		if file != "" {
+			part, _ := writer.CreateFormFile("file", "page.html")
+			part.Write([]byte(file))
+		}
+		writer.Close()
+		return body, writer.FormDataContentType()
+	}
+
+	tests := []struct {
+		name           string
+		buildRequest   func() *http.Request
+		setupMock      func(*MockAnalyzer)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "JSON body",
+			buildRequest: func() *http.Request {
+				body, _ := json.Marshal(domain.HTMLAnalysisRequest{HTML: html, BaseURL: "https://staging.example.com"})
+				req := httptest.NewRequest(http.MethodPost, "/analyze/html", bytes.NewBuffer(body))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			setupMock: func(ma *MockAnalyzer) {
+				ma.On("AnalyzeHTML", mock.Anything, domain.HTMLAnalysisRequest{HTML: html, BaseURL: "https://staging.example.com"}).
+					Return(analysis, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Raw HTML body",
+			buildRequest: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/analyze/html?baseUrl=https://staging.example.com", bytes.NewBufferString(html))
+				req.Header.Set("Content-Type", "text/html; charset=utf-8")
+				return req
+			},
+			setupMock: func(ma *MockAnalyzer) {
+				ma.On("AnalyzeHTML", mock.Anything, domain.HTMLAnalysisRequest{HTML: html, BaseURL: "https://staging.example.com"}).
+					Return(analysis, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Multipart file upload",
+			buildRequest: func() *http.Request {
+				body, contentType := multipartBody(map[string]string{"baseUrl": "https://staging.example.com"}, html)
+				req := httptest.NewRequest(http.MethodPost, "/analyze/html", body)
+				req.Header.Set("Content-Type", contentType)
+				return req
+			},
+			setupMock: func(ma *MockAnalyzer) {
+				ma.On("AnalyzeHTML", mock.Anything, domain.HTMLAnalysisRequest{HTML: html, BaseURL: "https://staging.example.com"}).
+					Return(analysis, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Multipart html field",
+			buildRequest: func() *http.Request {
+				body, contentType := multipartBody(map[string]string{"html": html}, "")
+				req := httptest.NewRequest(http.MethodPost, "/analyze/html", body)
+				req.Header.Set("Content-Type", contentType)
+				return req
+			},
+			setupMock: func(ma *MockAnalyzer) {
+				ma.On("AnalyzeHTML", mock.Anything, domain.HTMLAnalysisRequest{HTML: html}).
+					Return(analysis, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Empty HTML",
+			buildRequest: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/analyze/html", bytes.NewBufferString(""))
+				req.Header.Set("Content-Type", "text/html")
+				return req
+			},
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   domain.CodeValidationFailed,
+		},
+		{
+			name: "Invalid base URL",
+			buildRequest: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/analyze/html?baseUrl=not-a-url", bytes.NewBufferString(html))
+				req.Header.Set("Content-Type", "text/html")
+				return req
+			},
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   domain.CodeValidationFailed,
+		},
+		{
+			name: "Document too large",
+			buildRequest: func() *http.Request {
+				body := bytes.Repeat([]byte("a"), MaxHTMLSize+1)
+				req := httptest.NewRequest(http.MethodPost, "/analyze/html", bytes.NewBuffer(body))
+				req.Header.Set("Content-Type", "text/html")
+				return req
+			},
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   domain.CodeRequestTooLarge,
+		},
+		{
+			name: "Unsupported content type",
+			buildRequest: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/analyze/html", bytes.NewBufferString(html))
+				req.Header.Set("Content-Type", "application/xml")
+				return req
+			},
+			setupMock:      func(ma *MockAnalyzer) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   domain.CodeUnsupportedMedia,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAnalyzer := new(MockAnalyzer)
+			tt.setupMock(mockAnalyzer)
+			handler := NewAnalyzerHandler(mockAnalyzer, logger)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = tt.buildRequest()
+
+			handler.AnalyzeHTML(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var apiErr domain.APIError
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &apiErr))
+				assert.Equal(t, tt.expectedCode, apiErr.Code)
+			} else {
+				var result domain.PageAnalysis
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Equal(t, "Preview", result.PageTitle)
+			}
+
+			mockAnalyzer.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	"github.com/suraif16/webpage-analyzer/internal/httperr"
+	"go.uber.org/zap"
+)
+
+// APIKeyHandler serves the admin endpoints for managing API keys
+type APIKeyHandler struct {
+	keys   ports.APIKeyManager
+	logger *zap.Logger
+}
+
+func NewAPIKeyHandler(keys ports.APIKeyManager, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		keys:   keys,
+		logger: logger,
+	}
+}
+
+// Create godoc
+// @Summary Create an API key
+// @Description Creates a key and returns its plaintext once. Only a hash of the key is stored.
+// @Tags admin
+// @Accept json
+// @Produce json,application/problem+json
+// @Security ApiKeyAuth
+// @Param request body domain.CreateAPIKeyRequest true "Key name, role and limits"
+// @Success 201 {object} domain.CreatedAPIKey
+// @Failure 400 {object} domain.APIError
+// @Failure 401 {object} domain.APIError
+// @Failure 403 {object} domain.APIError
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	var req domain.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httperr.Write(c, bindingError(err))
+		return
+	}
+
+	created, err := h.keys.Create(c.Request.Context(), req)
+	if err != nil {
+		h.writeKeyError(c, "failed to create api key", err)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// List godoc
+// @Summary List API keys
+// @Tags admin
+// @Produce json,application/problem+json
+// @Security ApiKeyAuth
+// @Success 200 {array} domain.APIKey
+// @Failure 401 {object} domain.APIError
+// @Failure 403 {object} domain.APIError
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) List(c *gin.Context) {
+	keys, err := h.keys.List(c.Request.Context())
+	if err != nil {
+		h.writeKeyError(c, "failed to list api keys", err)
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// Revoke godoc
+// @Summary Revoke an API key
+// @Tags admin
+// @Produce json,application/problem+json
+// @Security ApiKeyAuth
+// @Param id path string true "Key ID"
+// @Success 204
+// @Failure 401 {object} domain.APIError
+// @Failure 403 {object} domain.APIError
+// @Failure 404 {object} domain.APIError
+// @Router /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	if err := h.keys.Revoke(c.Request.Context(), c.Param("id")); err != nil {
+		h.writeKeyError(c, "failed to revoke api key", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) writeKeyError(c *gin.Context, msg string, err error) {
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		httperr.Write(c, apiErr)
+		return
+	}
+	requestLogger(c, h.logger).Error(msg, zap.Error(err))
+	httperr.Write(c, domain.ErrInternalServer)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+type MockAPIKeyManager struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyManager) Create(ctx context.Context, req domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreatedAPIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyManager) Register(ctx context.Context, name, rawKey, role string) (*domain.APIKey, error) {
+	args := m.Called(ctx, name, rawKey, role)
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyManager) List(ctx context.Context) ([]domain.APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyManager) Revoke(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockAPIKeyManager) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	args := m.Called(ctx, rawKey)
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyManager) Allow(ctx context.Context, key domain.APIKey) error {
+	return m.Called(ctx, key).Error(0)
+}
+
+func (m *MockAPIKeyManager) Refund(ctx context.Context, key domain.APIKey) error {
+	return m.Called(ctx, key).Error(0)
+}
+
+func TestAPIKeyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	created := &domain.CreatedAPIKey{
+		APIKey: domain.APIKey{ID: "k1", Name: "ci", Prefix: "wpa_abcdefgh", Hash: "secret-hash", Role: domain.RoleClient},
+		Key:    "wpa_abcdefgh-rest-of-key",
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setupMock      func(*MockAPIKeyManager)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "Create key",
+			method: http.MethodPost,
+			path:   "/admin/api-keys",
+			body:   `{"name":"ci","dailyQuota":500}`,
+			setupMock: func(m *MockAPIKeyManager) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(req domain.CreateAPIKeyRequest) bool {
+					return req.Name == "ci" && req.DailyQuota != nil && *req.DailyQuota == 500
+				})).Return(created, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `"key":"wpa_abcdefgh-rest-of-key"`,
+		},
+		{
+			name:           "Create without name",
+			method:         http.MethodPost,
+			path:           "/admin/api-keys",
+			body:           `{"role":"client"}`,
+			setupMock:      func(m *MockAPIKeyManager) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   domain.CodeValidationFailed,
+		},
+		{
+			name:   "List keys",
+			method: http.MethodGet,
+			path:   "/admin/api-keys",
+			setupMock: func(m *MockAPIKeyManager) {
+				m.On("List", mock.Anything).Return([]domain.APIKey{created.APIKey}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"prefix":"wpa_abcdefgh"`,
+		},
+		{
+			name:   "Revoke key",
+			method: http.MethodDelete,
+			path:   "/admin/api-keys/k1",
+			setupMock: func(m *MockAPIKeyManager) {
+				m.On("Revoke", mock.Anything, "k1").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "Revoke unknown key",
+			method: http.MethodDelete,
+			path:   "/admin/api-keys/missing",
+			setupMock: func(m *MockAPIKeyManager) {
+				m.On("Revoke", mock.Anything, "missing").Return(domain.ErrAPIKeyNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   domain.CodeAPIKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := new(MockAPIKeyManager)
+			tt.setupMock(manager)
+			handler := NewAPIKeyHandler(manager, zap.NewNop())
+
+			r := gin.New()
+			r.POST("/admin/api-keys", handler.Create)
+			r.GET("/admin/api-keys", handler.List)
+			r.DELETE("/admin/api-keys/:id", handler.Revoke)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.NotContains(t, w.Body.String(), "secret-hash")
+			if w.Code == http.StatusOK {
+				assert.True(t, json.Valid(w.Body.Bytes()))
+			}
+			manager.AssertExpectations(t)
+		})
+	}
+}
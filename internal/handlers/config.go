@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+)
+
+// ConfigHandler exposes the effective configuration for troubleshooting
+type ConfigHandler struct {
+	config ports.ConfigReporter
+}
+
+func NewConfigHandler(config ports.ConfigReporter) *ConfigHandler {
+	return &ConfigHandler{config: config}
+}
+
+// Config godoc
+// @Summary Show the effective configuration
+// @Description Returns every setting after defaults, the config file, .env, environment variables and flags were applied.
+// @Description Secrets and proxy credentials are masked.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/config [get]
+func (h *ConfigHandler) Config(c *gin.Context) {
+	c.JSON(http.StatusOK, h.config.EffectiveConfig())
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubConfigReporter map[string]any
+
+func (s stubConfigReporter) EffectiveConfig() map[string]any {
+	return s
+}
+
+func TestConfigHandler_Config(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewConfigHandler(stubConfigReporter{"PORT": "8080", "ADMIN_API_KEY": "REDACTED"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+
+	handler.Config(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]any{"PORT": "8080", "ADMIN_API_KEY": "REDACTED"}, response)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+)
+
+// DebugHandler exposes internal runtime state for troubleshooting
+type DebugHandler struct {
+	breakers ports.CircuitBreakerReporter
+	limits   ports.HostLimitReporter
+}
+
+func NewDebugHandler(breakers ports.CircuitBreakerReporter, limits ports.HostLimitReporter) *DebugHandler {
+	return &DebugHandler{
+		breakers: breakers,
+		limits:   limits,
+	}
+}
+
+// CircuitBreakers godoc
+// @Summary List circuit breakers
+// @Description Returns the circuit breaker state of every host whose recent requests failed
+// @Tags debug
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} domain.CircuitBreakerState
+// @Router /debug/circuit-breakers [get]
+func (h *DebugHandler) CircuitBreakers(c *gin.Context) {
+	c.JSON(http.StatusOK, h.breakers.CircuitBreakers())
+}
+
+// HostLimits godoc
+// @Summary List per-host request limits
+// @Description Returns the politeness limiter of every recently contacted host, including how long requests queued for it
+// @Tags debug
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} domain.HostLimitStats
+// @Router /debug/host-limits [get]
+func (h *DebugHandler) HostLimits(c *gin.Context) {
+	c.JSON(http.StatusOK, h.limits.HostLimits())
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+type stubBreakerReporter struct {
+	states []domain.CircuitBreakerState
+}
+
+func (s stubBreakerReporter) CircuitBreakers() []domain.CircuitBreakerState {
+	return s.states
+}
+
+type stubHostLimitReporter struct {
+	stats []domain.HostLimitStats
+}
+
+func (s stubHostLimitReporter) HostLimits() []domain.HostLimitStats {
+	return s.stats
+}
+
+func TestDebugHandler_CircuitBreakers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	states := []domain.CircuitBreakerState{
+		{Host: "down.example.com", State: "open", ConsecutiveFailures: 5, LastFailure: "Connection Refused"},
+	}
+	handler := NewDebugHandler(stubBreakerReporter{states: states}, stubHostLimitReporter{})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/debug/circuit-breakers", nil)
+
+	handler.CircuitBreakers(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []domain.CircuitBreakerState
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, states, response)
+}
+
+func TestDebugHandler_HostLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stats := []domain.HostLimitStats{
+		{Host: "example.com", RequestsPerSecond: 0.5, CrawlDelayMs: 2000, QueuedRequests: 3, TotalWaitMs: 2500, MaxWaitMs: 1800},
+	}
+	handler := NewDebugHandler(stubBreakerReporter{}, stubHostLimitReporter{stats: stats})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/debug/host-limits", nil)
+
+	handler.HostLimits(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []domain.HostLimitStats
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, stats, response)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.uber.org/zap"
+)
+
+func init() {
+	// Report validation failures using the JSON field names clients send
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// requestLogger returns the request-scoped logger attached by the RequestID
+// middleware, or fallback when there is none
+func requestLogger(c *gin.Context, fallback *zap.Logger) *zap.Logger {
+	return logging.FromContext(c.Request.Context(), fallback)
+}
+
+// ValidateRequest checks a request against its binding tags and reports
+// failures the way the REST handlers do, so that other transports apply the
+// same rules
+func ValidateRequest(req any) *domain.APIError {
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return bindingError(err)
+	}
+	return nil
+}
+
+// bindingError converts an error returned while binding a request body into an
+// API error with field-level details
+func bindingError(err error) *domain.APIError {
+	var (
+		apiErr         *domain.APIError
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		maxBytesErr    *http.MaxBytesError
+	)
+
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr
+	case errors.As(err, &maxBytesErr):
+		return domain.ErrRequestTooLarge.WithCause(err)
+	case errors.As(err, &validationErrs):
+		fields := make([]domain.FieldError, 0, len(validationErrs))
+		onlyURL := true
+		for _, fe := range validationErrs {
+			fields = append(fields, domain.FieldError{
+				Field:   fe.Field(),
+				Rule:    fe.Tag(),
+				Message: validationMessage(fe),
+			})
+			if fe.Field() != "url" {
+				onlyURL = false
+			}
+		}
+		if onlyURL {
+			return domain.ErrInvalidURL.WithFields(fields).WithCause(err)
+		}
+		return domain.ErrValidationFailed.WithFields(fields).WithCause(err)
+	case errors.As(err, &typeErr):
+		return domain.ErrInvalidRequestBody.WithFields([]domain.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("must be of type %s", typeErr.Type),
+		}}).WithCause(err)
+	default:
+		return domain.ErrInvalidRequestBody.WithCause(err)
+	}
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if":
+		return "is required"
+	case "url":
+		return "must be a valid absolute URL"
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of: " + fe.Param()
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+)
+
+// HealthHandler serves liveness and readiness probes
+type HealthHandler struct {
+	health ports.HealthReporter
+}
+
+func NewHealthHandler(health ports.HealthReporter) *HealthHandler {
+	return &HealthHandler{health: health}
+}
+
+// Livez godoc
+// @Summary Liveness probe
+// @Description Reports whether the process is able to serve requests at all. A failure means it should be restarted.
+// @Tags health
+// @Produce json
+// @Success 200 {object} domain.HealthReport
+// @Failure 503 {object} domain.HealthReport
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *gin.Context) {
+	writeReport(c, h.health.Live(c.Request.Context()))
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Reports whether the service should receive traffic: its key store answers, outbound requests are not backed up,
+// @Description outbound DNS and connections work and it is not shutting down. Every check lists its latency and details.
+// @Tags health
+// @Produce json
+// @Success 200 {object} domain.HealthReport
+// @Failure 503 {object} domain.HealthReport
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	writeReport(c, h.health.Ready(c.Request.Context()))
+}
+
+func writeReport(c *gin.Context, report domain.HealthReport) {
+	status := http.StatusOK
+	if report.Status != domain.HealthUp {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
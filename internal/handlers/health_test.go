@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+type stubHealthReporter struct {
+	live, ready domain.HealthReport
+}
+
+func (s stubHealthReporter) Live(ctx context.Context) domain.HealthReport  { return s.live }
+func (s stubHealthReporter) Ready(ctx context.Context) domain.HealthReport { return s.ready }
+
+func TestHealthHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	up := domain.HealthReport{Status: domain.HealthUp, Checks: []domain.HealthCheckResult{}}
+	down := domain.HealthReport{
+		Status: domain.HealthDown,
+		Checks: []domain.HealthCheckResult{{Name: "drain", Status: domain.HealthDown, Error: "server is shutting down"}},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		reporter       stubHealthReporter
+		expectedStatus int
+		expectedReport domain.HealthReport
+	}{
+		{name: "Live", path: "/livez", reporter: stubHealthReporter{live: up, ready: down}, expectedStatus: http.StatusOK, expectedReport: up},
+		{name: "Ready", path: "/readyz", reporter: stubHealthReporter{live: up, ready: up}, expectedStatus: http.StatusOK, expectedReport: up},
+		{name: "Not ready", path: "/readyz", reporter: stubHealthReporter{live: up, ready: down}, expectedStatus: http.StatusServiceUnavailable, expectedReport: down},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(tt.reporter)
+			r := gin.New()
+			r.GET("/livez", handler.Livez)
+			r.GET("/readyz", handler.Readyz)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var report domain.HealthReport
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			assert.Equal(t, tt.expectedReport, report)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Package httperr renders API errors for the REST handlers and middleware, so
+// that every rejected request gets the same error format
+package httperr
+
+import (
+	"math"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+)
+
+const problemContentType = "application/problem+json"
+
+// Write renders an API error. Clients that accept application/problem+json
+// receive an RFC 7807 document; everyone else gets the APIError shape.
+// The ID of the request is included so that reports can be matched to logs.
+func Write(c *gin.Context, apiErr *domain.APIError) {
+	if id := logging.RequestID(c.Request.Context()); id != "" {
+		withID := *apiErr
+		withID.RequestID = id
+		apiErr = &withID
+	}
+	if acceptsProblem(c.GetHeader("Accept")) {
+		c.Header("Content-Type", problemContentType)
+		c.JSON(apiErr.StatusCode, apiErr.Problem(c.Request.URL.RequestURI()))
+		return
+	}
+	c.JSON(apiErr.StatusCode, apiErr)
+}
+
+// Abort renders an API error and stops the handler chain, so that requests
+// rejected by middleware get the same error format as handlers use
+func Abort(c *gin.Context, apiErr *domain.APIError) {
+	if apiErr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(apiErr.RetryAfter.Seconds()))))
+	}
+	Write(c, apiErr)
+	c.Abort()
+}
+
+// acceptsProblem reports whether the Accept header explicitly lists
+// application/problem+json with a non-zero quality
+func acceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != problemContentType {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package httperr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+func TestAcceptsProblem(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{accept: "", expected: false},
+		{accept: "application/json", expected: false},
+		{accept: "*/*", expected: false},
+		{accept: "application/problem+json", expected: true},
+		{accept: "application/json, application/problem+json;q=0.8", expected: true},
+		{accept: "application/problem+json;q=0", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			assert.Equal(t, tt.expected, acceptsProblem(tt.accept))
+		})
+	}
+}
+
+func TestAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/limited", func(c *gin.Context) {
+		Abort(c, domain.ErrRateLimited.WithRetryAfter(1500*time.Millisecond))
+	}, func(c *gin.Context) {
+		t.Error("handler chain was not stopped")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/limited", nil))
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), domain.ErrRateLimited.Code)
+}
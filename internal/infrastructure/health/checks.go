@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+)
+
+// storeCheck verifies that the API key store answers
+type storeCheck struct {
+	store ports.APIKeyStore
+}
+
+func NewStoreCheck(store ports.APIKeyStore) ports.HealthCheck {
+	return storeCheck{store: store}
+}
+
+func (s storeCheck) Name() string { return "api_key_store" }
+
+func (s storeCheck) Check(ctx context.Context) (map[string]any, error) {
+	return nil, s.store.Ping(ctx)
+}
+
+// queueCheck fails when too many outbound requests wait for per-host
+// politeness slots, which means new analyses would mostly queue
+type queueCheck struct {
+	limits    ports.HostLimitReporter
+	maxQueued int64
+}
+
+func NewQueueCheck(limits ports.HostLimitReporter, maxQueued int64) ports.HealthCheck {
+	return queueCheck{limits: limits, maxQueued: maxQueued}
+}
+
+func (q queueCheck) Name() string { return "outbound_queue" }
+
+func (q queueCheck) Check(ctx context.Context) (map[string]any, error) {
+	var queued, inFlight int64
+	hosts := q.limits.HostLimits()
+	for _, h := range hosts {
+		queued += int64(h.Waiting)
+		inFlight += int64(h.InFlight)
+	}
+
+	details := map[string]any{
+		"queued":    queued,
+		"inFlight":  inFlight,
+		"hosts":     len(hosts),
+		"maxQueued": q.maxQueued,
+	}
+	if q.maxQueued > 0 && queued > q.maxQueued {
+		return details, fmt.Errorf("%d requests queued, limit is %d", queued, q.maxQueued)
+	}
+	return details, nil
+}
+
+// egressCheck resolves and connects to a probe target to verify outbound DNS
+// and network access. Results are reused for interval so that frequent
+// probes do not turn into a stream of connections to the target.
+type egressCheck struct {
+	target   string
+	interval time.Duration
+	resolver *net.Resolver
+	dialer   *net.Dialer
+
+	mu      sync.Mutex
+	checked time.Time
+	details map[string]any
+	err     error
+}
+
+// NewEgressCheck probes target, a host:port such as example.com:443
+func NewEgressCheck(target string, interval time.Duration) ports.HealthCheck {
+	return &egressCheck{
+		target:   target,
+		interval: interval,
+		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{},
+	}
+}
+
+func (e *egressCheck) Name() string { return "egress" }
+
+func (e *egressCheck) Check(ctx context.Context) (map[string]any, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.checked.IsZero() && time.Since(e.checked) < e.interval {
+		return e.details, e.err
+	}
+	e.details, e.err = e.probe(ctx)
+	e.checked = time.Now()
+	return e.details, e.err
+}
+
+func (e *egressCheck) probe(ctx context.Context) (map[string]any, error) {
+	host, port, err := net.SplitHostPort(e.target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid probe target %q: %w", e.target, err)
+	}
+	details := map[string]any{"target": e.target}
+
+	start := time.Now()
+	addrs, err := e.resolver.LookupHost(ctx, host)
+	details["dnsMs"] = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		return details, fmt.Errorf("dns lookup failed: %w", err)
+	}
+
+	start = time.Now()
+	conn, err := e.dialer.DialContext(ctx, "tcp", net.JoinHostPort(addrs[0], port))
+	details["connectMs"] = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		return details, fmt.Errorf("connect failed: %w", err)
+	}
+	conn.Close()
+	return details, nil
+}
@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	httpclient "github.com/suraif16/webpage-analyzer/internal/infrastructure/http/client"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/store/memory"
+	"go.uber.org/zap"
+)
+
+type stubHostLimitReporter []domain.HostLimitStats
+
+func (s stubHostLimitReporter) HostLimits() []domain.HostLimitStats {
+	return s
+}
+
+func TestStoreCheck(t *testing.T) {
+	check := NewStoreCheck(memory.NewAPIKeyStore())
+
+	_, err := check.Check(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = check.Check(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestQueueCheck(t *testing.T) {
+	limits := stubHostLimitReporter{
+		{Host: "a.com", Waiting: 4, QueuedRequests: 40, InFlight: 2},
+		{Host: "b.com", Waiting: 3, QueuedRequests: 30, InFlight: 1},
+	}
+
+	details, err := NewQueueCheck(limits, 10).Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), details["queued"])
+	assert.Equal(t, int64(3), details["inFlight"])
+
+	_, err = NewQueueCheck(limits, 5).Check(context.Background())
+	assert.EqualError(t, err, "7 requests queued, limit is 5")
+
+	_, err = NewQueueCheck(limits, 0).Check(context.Background())
+	assert.NoError(t, err, "a limit of 0 disables the check")
+}
+
+func TestQueueCheck_DrainedQueue(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		<-release
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := httpclient.NewHTTPClient(5*time.Second, zap.NewNop(),
+		httpclient.WithPoliteness(httpclient.PolitenessPolicy{MaxConnsPerHost: 1}))
+	check := NewQueueCheck(client, 2)
+
+	// One request holds the only slot and the others wait behind it
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+			assert.NoError(t, err)
+		}()
+	}
+	assert.Eventually(t, func() bool {
+		_, err := check.Check(context.Background())
+		return err != nil
+	}, 5*time.Second, 5*time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	// Requests that waited in the past do not keep the service unready
+	details, err := check.Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), details["queued"])
+}
+
+func TestEgressCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	target := listener.Addr().String()
+
+	check := NewEgressCheck(target, time.Minute)
+	details, err := check.Check(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, target, details["target"])
+	assert.Contains(t, details, "connectMs")
+
+	// The cached result is served while the interval has not passed
+	listener.Close()
+	_, err = check.Check(context.Background())
+	assert.NoError(t, err)
+
+	_, err = NewEgressCheck(target, 0).Check(context.Background())
+	var opErr *net.OpError
+	assert.True(t, errors.As(err, &opErr), "expected a connect error, got %v", err)
+
+	_, err = NewEgressCheck("no-port", 0).Check(context.Background())
+	assert.ErrorContains(t, err, "invalid probe target")
+}
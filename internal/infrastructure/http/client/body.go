@@ -0,0 +1,60 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// DefaultMaxBodySize is the largest decoded page FetchPage reads when the
+// client is not configured otherwise
+const DefaultMaxBodySize int64 = 10 << 20
+
+// body is a response body read within the configured size limit
+type body struct {
+	data           []byte
+	compressedSize int64
+	encoding       string
+	truncated      bool
+}
+
+// readBody reads at most limit decoded bytes of the response. When the page is
+// larger it either fails with ErrPageTooLarge or, if allowTruncated is set,
+// returns the first limit bytes marked as truncated.
+func readBody(resp *http.Response, limit int64, allowTruncated bool) (*body, error) {
+	// Content-Length is the encoded size, so the decoded page is at least as large
+	if !allowTruncated && resp.ContentLength > limit {
+		return nil, domain.ErrPageTooLarge
+	}
+
+	// Decompress ourselves so both the wire and decoded sizes can be reported
+	compressed := &countingReader{r: resp.Body}
+	codings := contentEncodings(resp)
+	reader, closeDecoders, err := decodeContent(compressed, codings)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDecoders()
+
+	// Reading one byte past the limit tells a page of exactly limit bytes
+	// apart from a larger one without buffering the rest of it
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	b := &body{data: data, compressedSize: compressed.n, encoding: domain.EncodingIdentity}
+	if len(codings) > 0 {
+		b.encoding = strings.Join(codings, ", ")
+	}
+	if int64(len(data)) > limit {
+		if !allowTruncated {
+			return nil, domain.ErrPageTooLarge
+		}
+		b.data = data[:limit]
+		b.truncated = true
+	}
+	return b, nil
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// Circuit breaker states
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half-open"
+)
+
+// BreakerPolicy configures the per-host circuit breaker
+type BreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit
+	FailureThreshold int
+	// Cooldown is how long an open circuit rejects requests before letting a
+	// single trial request through
+	Cooldown time.Duration
+}
+
+type hostBreaker struct {
+	state       string
+	failures    int
+	openedAt    time.Time
+	lastFailure string
+}
+
+// breakerRegistry tracks one circuit breaker per host
+type breakerRegistry struct {
+	mu     sync.Mutex
+	policy BreakerPolicy
+	hosts  map[string]*hostBreaker
+	now    func() time.Time
+}
+
+func newBreakerRegistry(policy BreakerPolicy) *breakerRegistry {
+	return &breakerRegistry{
+		policy: policy,
+		hosts:  make(map[string]*hostBreaker),
+		now:    time.Now,
+	}
+}
+
+// allow reports whether a request to host may be sent. When the circuit is
+// open it also returns how long until the next trial request is allowed.
+func (r *breakerRegistry) allow(host string) (bool, time.Duration) {
+	if r.policy.FailureThreshold <= 0 {
+		return true, 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.hosts[host]
+	if !ok {
+		return true, 0
+	}
+
+	switch b.state {
+	case breakerOpen:
+		wait := b.openedAt.Add(r.policy.Cooldown).Sub(r.now())
+		if wait > 0 {
+			return false, wait
+		}
+		// Let one request through to find out whether the host recovered
+		b.state = breakerHalfOpen
+		return true, 0
+	case breakerHalfOpen:
+		// A trial request is already in flight
+		return false, r.policy.Cooldown
+	default:
+		return true, 0
+	}
+}
+
+// record updates the breaker for host with the outcome of a request
+func (r *breakerRegistry) record(host string, err error) {
+	if r.policy.FailureThreshold <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.hosts[host]
+	if err == nil {
+		if ok {
+			delete(r.hosts, host)
+		}
+		return
+	}
+
+	if !ok {
+		b = &hostBreaker{state: breakerClosed}
+		r.hosts[host] = b
+	}
+	b.failures++
+	b.lastFailure = err.Error()
+
+	if b.state == breakerHalfOpen || b.failures >= r.policy.FailureThreshold {
+		b.state = breakerOpen
+		b.openedAt = r.now()
+	}
+}
+
+// release gives back a trial request that ended without showing whether the
+// host recovered, such as one cancelled by the caller, so that the next request
+// becomes the trial instead of the circuit staying half-open
+func (r *breakerRegistry) release(host string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if b, ok := r.hosts[host]; ok && b.state == breakerHalfOpen {
+		b.state = breakerOpen
+	}
+}
+
+// snapshot returns the state of every host that has recently failed
+func (r *breakerRegistry) snapshot() []domain.CircuitBreakerState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	states := make([]domain.CircuitBreakerState, 0, len(r.hosts))
+	for host, b := range r.hosts {
+		state := domain.CircuitBreakerState{
+			Host:                host,
+			State:               b.state,
+			ConsecutiveFailures: b.failures,
+			LastFailure:         b.lastFailure,
+		}
+		if !b.openedAt.IsZero() {
+			openedAt := b.openedAt
+			state.OpenedAt = &openedAt
+		}
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Host < states[j].Host })
+	return states
+}
@@ -0,0 +1,372 @@
+package http
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// responseCache is an in-memory shared cache of fetched pages following the
+// storage, freshness and validation rules of RFC 9111. Entries are evicted in
+// least recently used order once either limit is reached.
+type responseCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	size       int64
+	entries    map[string]*list.Element
+	lru        *list.List
+	now        func() time.Time
+}
+
+// cacheEntry is a stored page along with what is needed to judge its freshness
+type cacheEntry struct {
+	key    string
+	result domain.FetchResult
+	// header is the stored response header, updated on every revalidation
+	header       http.Header
+	etag         string
+	lastModified string
+	// responseTime is when the stored response was received
+	responseTime time.Time
+	// initialAge is the age of the response when it was received
+	initialAge time.Duration
+	lifetime   time.Duration
+}
+
+func newResponseCache(maxEntries int, maxBytes int64) *responseCache {
+	if maxEntries <= 0 {
+		return nil
+	}
+	return &responseCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// key returns the cache key of a request and whether it may use the cache.
+// Requests carrying credentials or options that change the response are never
+// served from or stored in the shared cache. The other options that change how
+// the page is fetched are part of the key, so that for instance a page reached
+// through redirects is not served to a request allowing none.
+func (c *responseCache) key(url string, opts domain.FetchOptions) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	if len(opts.Headers) > 0 || len(opts.Cookies) > 0 || opts.Auth != nil ||
+		opts.UserAgent != "" || opts.AcceptLanguage != "" ||
+		opts.MaxBodyBytes > 0 || opts.Proxy != "" {
+		return "", false
+	}
+
+	key := url
+	if opts.MaxRedirects != nil {
+		key += "\x00redirects=" + strconv.Itoa(*opts.MaxRedirects)
+	}
+	if opts.TimeoutMs > 0 {
+		key += "\x00timeout=" + strconv.Itoa(opts.TimeoutMs)
+	}
+	if opts.AllowTruncated {
+		key += "\x00truncated"
+	}
+	return key, true
+}
+
+// get returns a copy of the entry stored under key
+func (c *responseCache) get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	c.lru.MoveToFront(el)
+	return *el.Value.(*cacheEntry), true
+}
+
+// store saves a freshly downloaded page when its response allows it
+func (c *responseCache) store(key string, resp *http.Response, result *domain.FetchResult) *domain.CacheInfo {
+	info := &domain.CacheInfo{
+		Status:       domain.CacheMiss,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	cc := parseCacheControl(resp.Header)
+	lifetime := freshnessLifetime(resp.Header, cc)
+	if !storable(resp, cc) || result.Truncated ||
+		(lifetime <= 0 && info.ETag == "" && info.LastModified == "") ||
+		(c.maxBytes > 0 && int64(len(result.Body)) > c.maxBytes) {
+		c.remove(key)
+		return info
+	}
+
+	now := c.now()
+	entry := &cacheEntry{
+		key:          key,
+		result:       *result,
+		header:       resp.Header.Clone(),
+		etag:         info.ETag,
+		lastModified: info.LastModified,
+		responseTime: now,
+		initialAge:   initialAge(resp.Header, now),
+		lifetime:     lifetime,
+	}
+	entry.result.Cache = nil
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.size -= int64(len(el.Value.(*cacheEntry).result.Body))
+		el.Value = entry
+		c.lru.MoveToFront(el)
+	} else {
+		c.entries[key] = c.lru.PushFront(entry)
+	}
+	c.size += int64(len(entry.result.Body))
+	c.evict()
+
+	info.Stored = true
+	return info
+}
+
+// refresh updates an entry with the headers of a 304 response as described in
+// RFC 9111 section 4.3.4 and returns the updated copy. Fields the 304 leaves
+// out, commonly Cache-Control, keep their stored values.
+func (c *responseCache) refresh(entry cacheEntry, resp *http.Response) cacheEntry {
+	now := c.now()
+	entry.header = mergeHeaders(entry.header, resp.Header)
+	entry.etag = entry.header.Get("ETag")
+	entry.lastModified = entry.header.Get("Last-Modified")
+	entry.responseTime = now
+	entry.initialAge = initialAge(entry.header, now)
+	entry.lifetime = freshnessLifetime(entry.header, parseCacheControl(entry.header))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[entry.key]; ok {
+		stored := entry
+		el.Value = &stored
+		c.lru.MoveToFront(el)
+	}
+	return entry
+}
+
+// unmergedHeaders describe a single message rather than the stored response,
+// so a 304 never updates them (RFC 9111 section 3.2)
+var unmergedHeaders = []string{"Connection", "Content-Length", "Keep-Alive", "Proxy-Connection", "TE", "Trailer", "Transfer-Encoding", "Upgrade"}
+
+// mergeHeaders returns the stored header with every field of update replacing
+// the stored one. The stored Age only applied to the earlier response.
+func mergeHeaders(stored, update http.Header) http.Header {
+	merged := stored.Clone()
+	if merged == nil {
+		merged = http.Header{}
+	}
+	merged.Del("Age")
+	for name, values := range update {
+		merged[name] = append([]string(nil), values...)
+	}
+	for _, name := range unmergedHeaders {
+		if values, ok := stored[name]; ok {
+			merged[name] = values
+		} else {
+			merged.Del(name)
+		}
+	}
+	return merged
+}
+
+func (c *responseCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.size -= int64(len(el.Value.(*cacheEntry).result.Body))
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// evict drops the least recently used entries until both limits are met.
+// Callers must hold c.mu.
+func (c *responseCache) evict() {
+	for c.lru.Len() > c.maxEntries || (c.maxBytes > 0 && c.size > c.maxBytes) {
+		el := c.lru.Back()
+		entry := el.Value.(*cacheEntry)
+		c.size -= int64(len(entry.result.Body))
+		c.lru.Remove(el)
+		delete(c.entries, entry.key)
+	}
+}
+
+// age is the current age of the entry (RFC 9111 section 4.2.3)
+func (e cacheEntry) age(now time.Time) time.Duration {
+	return e.initialAge + now.Sub(e.responseTime)
+}
+
+func (e cacheEntry) fresh(now time.Time) bool {
+	return e.age(now) < e.lifetime
+}
+
+// validatorsKey carries the validators of a cached page from FetchPage to follow
+type validatorsKey struct{}
+
+// validators are the conditional request headers of a cached page. They are
+// only sent to the origin the page was served from, not to other hosts met
+// along a redirect chain.
+type validators struct {
+	origin  *url.URL
+	headers map[string]string
+}
+
+// withValidators stores the entry's validators in ctx
+func (e cacheEntry) withValidators(ctx context.Context) context.Context {
+	origin, err := url.Parse(e.result.Redirects.FinalURL)
+	if err != nil {
+		return ctx
+	}
+	v := validators{origin: origin, headers: make(map[string]string, 2)}
+	if e.etag != "" {
+		v.headers["If-None-Match"] = e.etag
+	}
+	if e.lastModified != "" {
+		v.headers["If-Modified-Since"] = e.lastModified
+	}
+	return context.WithValue(ctx, validatorsKey{}, v)
+}
+
+// applyValidators sets the validators carried by ctx on req when it goes to
+// their origin
+func applyValidators(ctx context.Context, req *http.Request) {
+	v, ok := ctx.Value(validatorsKey{}).(validators)
+	if !ok || !sameOrigin(v.origin, req.URL) {
+		return
+	}
+	for name, value := range v.headers {
+		req.Header.Set(name, value)
+	}
+}
+
+// served returns the stored page as a new result reporting the given cache status
+func (e cacheEntry) served(status string, now time.Time) *domain.FetchResult {
+	result := e.result
+	result.Cache = &domain.CacheInfo{
+		Status:       status,
+		Stored:       true,
+		AgeSeconds:   int64(e.age(now) / time.Second),
+		ETag:         e.etag,
+		LastModified: e.lastModified,
+	}
+	return &result
+}
+
+// cacheControl holds the directives of the Cache-Control header
+type cacheControl map[string]string
+
+func parseCacheControl(h http.Header) cacheControl {
+	cc := cacheControl{}
+	for _, header := range h.Values("Cache-Control") {
+		for _, directive := range strings.Split(header, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name == "" {
+				continue
+			}
+			cc[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return cc
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
+
+// seconds returns the value of a delta-seconds directive
+func (cc cacheControl) seconds(directive string) (time.Duration, bool) {
+	value, ok := cc[directive]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return time.Duration(n) * time.Second, true
+}
+
+// storable reports whether a shared cache may store the response
+// (RFC 9111 section 3)
+func storable(resp *http.Response, cc cacheControl) bool {
+	if resp.StatusCode != http.StatusOK || resp.Request == nil || resp.Request.Method != http.MethodGet {
+		return false
+	}
+	if cc.has("no-store") || cc.has("private") {
+		return false
+	}
+	for _, vary := range resp.Header.Values("Vary") {
+		if strings.Contains(vary, "*") {
+			return false
+		}
+	}
+	return true
+}
+
+// freshnessLifetime is how long a response stays fresh in a shared cache
+// (RFC 9111 section 4.2.1). Responses without explicit freshness are treated
+// as stale so they are always revalidated.
+func freshnessLifetime(h http.Header, cc cacheControl) time.Duration {
+	if cc.has("no-cache") {
+		return 0
+	}
+	if d, ok := cc.seconds("s-maxage"); ok {
+		return d
+	}
+	if d, ok := cc.seconds("max-age"); ok {
+		return d
+	}
+	if expires := h.Get("Expires"); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		if err != nil {
+			// Invalid dates, such as 0, represent a time in the past
+			return 0
+		}
+		date, err := http.ParseTime(h.Get("Date"))
+		if err != nil {
+			return 0
+		}
+		if d := expiresAt.Sub(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// initialAge is the corrected initial age of a response received at
+// responseTime, taking both the Age header and the Date header into account
+func initialAge(h http.Header, responseTime time.Time) time.Duration {
+	var age time.Duration
+	if seconds, err := strconv.ParseInt(h.Get("Age"), 10, 64); err == nil && seconds > 0 {
+		age = time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(h.Get("Date")); err == nil {
+		if apparent := responseTime.Sub(date); apparent > age {
+			age = apparent
+		}
+	}
+	return age
+}
@@ -0,0 +1,368 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+func TestHTTPClient_FetchPageCache(t *testing.T) {
+	tests := []struct {
+		name             string
+		handler          func(w http.ResponseWriter, r *http.Request)
+		opts             domain.FetchOptions
+		expectedStatuses []string
+		expectedRequests int32
+	}{
+		{
+			name: "Fresh page served from cache",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Cache-Control", "public, max-age=60")
+				w.Write([]byte("<html><title>v1</title></html>"))
+			},
+			expectedStatuses: []string{domain.CacheMiss, domain.CacheHit, domain.CacheHit},
+			expectedRequests: 1,
+		},
+		{
+			name: "ETag revalidation",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Cache-Control", "no-cache")
+				w.Header().Set("ETag", `"v1"`)
+				if r.Header.Get("If-None-Match") == `"v1"` {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Write([]byte("<html><title>v1</title></html>"))
+			},
+			expectedStatuses: []string{domain.CacheMiss, domain.CacheRevalidated, domain.CacheRevalidated},
+			expectedRequests: 3,
+		},
+		{
+			name: "Last-Modified revalidation",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				lastModified := "Mon, 02 Jan 2006 15:04:05 GMT"
+				w.Header().Set("Cache-Control", "max-age=0")
+				w.Header().Set("Last-Modified", lastModified)
+				if r.Header.Get("If-Modified-Since") == lastModified {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Write([]byte("<html><title>v1</title></html>"))
+			},
+			expectedStatuses: []string{domain.CacheMiss, domain.CacheRevalidated},
+			expectedRequests: 2,
+		},
+		{
+			name: "Validator without explicit freshness",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v2"`)
+				if r.Header.Get("If-None-Match") == `"v2"` {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Write([]byte("<html><title>v1</title></html>"))
+			},
+			expectedStatuses: []string{domain.CacheMiss, domain.CacheRevalidated},
+			expectedRequests: 2,
+		},
+		{
+			name: "No-store is never cached",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Cache-Control", "no-store, max-age=60")
+				w.Header().Set("ETag", `"v1"`)
+				w.Write([]byte("<html><title>v1</title></html>"))
+			},
+			expectedStatuses: []string{domain.CacheMiss, domain.CacheMiss},
+			expectedRequests: 2,
+		},
+		{
+			name: "Private responses are not shared",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Cache-Control", "private, max-age=60")
+				w.Write([]byte("<html><title>v1</title></html>"))
+			},
+			expectedStatuses: []string{domain.CacheMiss, domain.CacheMiss},
+			expectedRequests: 2,
+		},
+		{
+			name: "Requests with credentials bypass the cache",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Cache-Control", "max-age=60")
+				w.Write([]byte("<html><title>v1</title></html>"))
+			},
+			opts:             domain.FetchOptions{Headers: map[string]string{"Authorization": "Bearer abc"}},
+			expectedStatuses: []string{domain.CacheBypass, domain.CacheBypass},
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				w.Header().Set("Content-Type", "text/html")
+				tt.handler(w, r)
+			}))
+			defer server.Close()
+
+			client := NewHTTPClient(5*time.Second, zap.NewNop(), WithCache(10, 0))
+			for _, expected := range tt.expectedStatuses {
+				result, err := client.FetchPage(context.Background(), server.URL, tt.opts)
+				assert.NoError(t, err)
+				if assert.NotNil(t, result.Cache) {
+					assert.Equal(t, expected, result.Cache.Status)
+				}
+				assert.Contains(t, result.Body, "<title>v1</title>")
+			}
+			assert.Equal(t, tt.expectedRequests, atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestHTTPClient_FetchPageCacheHitTimings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Cache-Control", "max-age=60")
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte("<html><title>v1</title></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(), WithCache(10, 0))
+	miss, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+	assert.NoError(t, err)
+	assert.Positive(t, miss.Performance.TotalMs)
+
+	hit, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CacheHit, hit.Cache.Status)
+	assert.Equal(t, domain.PerformanceMetrics{
+		CompressedSize:   miss.Performance.CompressedSize,
+		UncompressedSize: miss.Performance.UncompressedSize,
+		ContentEncoding:  miss.Performance.ContentEncoding,
+		CompressionRatio: miss.Performance.CompressionRatio,
+	}, hit.Performance)
+}
+
+func TestHTTPClient_FetchPageCacheValidatorsStayOnOrigin(t *testing.T) {
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("<html><title>v1</title></html>"))
+	}))
+	defer page.Close()
+	pageURL, _ := url.Parse(page.URL)
+
+	var conditional []string
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		http.Redirect(w, r, "http://localhost:"+pageURL.Port()+"/", http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(), WithCache(10, 0))
+	for _, expected := range []string{domain.CacheMiss, domain.CacheRevalidated} {
+		result, err := client.FetchPage(context.Background(), redirect.URL, domain.FetchOptions{})
+		assert.NoError(t, err)
+		if assert.NotNil(t, result.Cache) {
+			assert.Equal(t, expected, result.Cache.Status)
+		}
+	}
+
+	// The redirecting host never sees the validators of the other host's page
+	assert.Equal(t, []string{"", ""}, conditional)
+}
+
+func TestHTTPClient_FetchPageCacheDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop())
+	result, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+	assert.NoError(t, err)
+	assert.Nil(t, result.Cache)
+}
+
+func TestResponseCache_Eviction(t *testing.T) {
+	cache := newResponseCache(2, 10)
+	resp := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Cache-Control": {"max-age=60"}},
+			Request:    &http.Request{Method: http.MethodGet},
+		}
+	}
+
+	assert.True(t, cache.store("a", resp(), &domain.FetchResult{Body: "aaaa"}).Stored)
+	assert.True(t, cache.store("b", resp(), &domain.FetchResult{Body: "bbbb"}).Stored)
+
+	// Using a keeps it while b becomes the least recently used entry
+	_, ok := cache.get("a")
+	assert.True(t, ok)
+	assert.True(t, cache.store("c", resp(), &domain.FetchResult{Body: "cccc"}).Stored)
+
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+	_, ok = cache.get("a")
+	assert.True(t, ok)
+
+	// Pages larger than the byte limit are not stored at all
+	assert.False(t, cache.store("d", resp(), &domain.FetchResult{Body: "ddddddddddddd"}).Stored)
+	assert.Equal(t, int64(8), cache.size)
+}
+
+func TestResponseCache_RefreshKeepsStoredFreshness(t *testing.T) {
+	cache := newResponseCache(10, 0)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return start }
+
+	stored := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Cache-Control":  {"max-age=60"},
+			"Etag":           {`"v1"`},
+			"Date":           {start.Format(http.TimeFormat)},
+			"Age":            {"10"},
+			"Content-Length": {"4"},
+		},
+		Request: &http.Request{Method: http.MethodGet},
+	}
+	assert.True(t, cache.store("a", stored, &domain.FetchResult{Body: "page"}).Stored)
+
+	// Two minutes later the entry is stale and revalidated with a 304 that
+	// only carries a new ETag and Date
+	later := start.Add(2 * time.Minute)
+	cache.now = func() time.Time { return later }
+	entry, _ := cache.get("a")
+	assert.False(t, entry.fresh(later))
+
+	notModified := &http.Response{
+		StatusCode: http.StatusNotModified,
+		Header: http.Header{
+			"Etag":           {`"v2"`},
+			"Date":           {later.Format(http.TimeFormat)},
+			"Content-Length": {"0"},
+		},
+	}
+	entry = cache.refresh(entry, notModified)
+
+	assert.Equal(t, time.Minute, entry.lifetime)
+	assert.Equal(t, time.Duration(0), entry.initialAge)
+	assert.True(t, entry.fresh(later.Add(30*time.Second)))
+	assert.Equal(t, `"v2"`, entry.etag)
+	assert.Equal(t, "4", entry.header.Get("Content-Length"))
+
+	cached, _ := cache.get("a")
+	assert.True(t, cached.fresh(later.Add(30*time.Second)))
+}
+
+func TestResponseCache_Key(t *testing.T) {
+	cache := newResponseCache(10, 0)
+	noRedirects := 0
+
+	base, ok := cache.key("https://example.com/", domain.FetchOptions{})
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/", base)
+
+	seen := map[string]bool{base: true}
+	for _, opts := range []domain.FetchOptions{
+		{MaxRedirects: &noRedirects},
+		{TimeoutMs: 500},
+		{AllowTruncated: true},
+		{MaxRedirects: &noRedirects, AllowTruncated: true},
+	} {
+		key, ok := cache.key("https://example.com/", opts)
+		assert.True(t, ok)
+		assert.False(t, seen[key], "key %q is shared", key)
+		seen[key] = true
+	}
+
+	_, ok = cache.key("https://example.com/", domain.FetchOptions{UserAgent: "bot"})
+	assert.False(t, ok)
+}
+
+func TestFreshnessLifetime(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   http.Header
+		expected time.Duration
+	}{
+		{
+			name:     "max-age",
+			header:   http.Header{"Cache-Control": {"public, max-age=300"}},
+			expected: 300 * time.Second,
+		},
+		{
+			name:     "s-maxage wins for shared caches",
+			header:   http.Header{"Cache-Control": {"max-age=300, s-maxage=60"}},
+			expected: 60 * time.Second,
+		},
+		{
+			name:     "no-cache forces revalidation",
+			header:   http.Header{"Cache-Control": {"no-cache, max-age=300"}},
+			expected: 0,
+		},
+		{
+			name: "Expires relative to Date",
+			header: http.Header{
+				"Date":    {"Mon, 02 Jan 2006 15:04:05 GMT"},
+				"Expires": {"Mon, 02 Jan 2006 16:04:05 GMT"},
+			},
+			expected: time.Hour,
+		},
+		{
+			name: "max-age overrides Expires",
+			header: http.Header{
+				"Cache-Control": {"max-age=10"},
+				"Date":          {"Mon, 02 Jan 2006 15:04:05 GMT"},
+				"Expires":       {"Mon, 02 Jan 2006 16:04:05 GMT"},
+			},
+			expected: 10 * time.Second,
+		},
+		{
+			name:     "Invalid Expires is already expired",
+			header:   http.Header{"Date": {"Mon, 02 Jan 2006 15:04:05 GMT"}, "Expires": {"0"}},
+			expected: 0,
+		},
+		{
+			name:     "No explicit freshness",
+			header:   http.Header{"Last-Modified": {"Mon, 02 Jan 2006 15:04:05 GMT"}},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, freshnessLifetime(tt.header, parseCacheControl(tt.header)))
+		})
+	}
+}
+
+func TestInitialAge(t *testing.T) {
+	received := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, 30*time.Second, initialAge(http.Header{"Age": {"30"}}, received))
+	assert.Equal(t, 2*time.Minute, initialAge(http.Header{
+		"Age":  {"30"},
+		"Date": {received.Add(-2 * time.Minute).Format(http.TimeFormat)},
+	}, received))
+	assert.Zero(t, initialAge(http.Header{}, received))
+}
@@ -0,0 +1,212 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// cdnSignature identifies a CDN by a header it adds to responses
+type cdnSignature struct {
+	provider string
+	header   string
+	// contains must appear in the header value when set
+	contains string
+}
+
+// cdnSignatures are checked in order, so more specific headers come first
+var cdnSignatures = []cdnSignature{
+	{provider: "Cloudflare", header: "CF-Ray"},
+	{provider: "Cloudflare", header: "CF-Cache-Status"},
+	{provider: "Amazon CloudFront", header: "X-Amz-Cf-Id"},
+	{provider: "Amazon CloudFront", header: "Via", contains: "cloudfront"},
+	{provider: "Fastly", header: "X-Fastly-Request-Id"},
+	{provider: "Fastly", header: "X-Served-By", contains: "cache-"},
+	{provider: "Akamai", header: "X-Akamai-Transformed"},
+	{provider: "Akamai", header: "Server", contains: "akamai"},
+	{provider: "Azure Front Door", header: "X-Azure-Ref"},
+	{provider: "Vercel", header: "X-Vercel-Cache"},
+	{provider: "Netlify", header: "X-Nf-Request-Id"},
+	{provider: "BunnyCDN", header: "Server", contains: "bunnycdn"},
+	{provider: "Google Cloud CDN", header: "Via", contains: "google"},
+	{provider: "Cloudflare", header: "Server", contains: "cloudflare"},
+	{provider: "Varnish", header: "X-Varnish"},
+	{provider: "Varnish", header: "Via", contains: "varnish"},
+}
+
+// cacheStatusHeaders report whether a CDN served the response from its cache
+var cacheStatusHeaders = []string{
+	"CF-Cache-Status",
+	"X-Vercel-Cache",
+	"CDN-Cache",
+	"X-Cache",
+	"X-Cache-Status",
+}
+
+// analyzeCaching interprets the caching and CDN headers of a response
+func analyzeCaching(h http.Header) domain.CachingAnalysis {
+	cc := parseCacheControl(h)
+	lifetime := freshnessLifetime(h, cc)
+
+	a := domain.CachingAnalysis{
+		CacheControl:  strings.Join(h.Values("Cache-Control"), ", "),
+		Expires:       h.Get("Expires"),
+		ETag:          h.Get("ETag"),
+		LastModified:  h.Get("Last-Modified"),
+		Vary:          headerList(h, "Vary"),
+		Via:           h.Get("Via"),
+		MaxAgeSeconds: int64(lifetime / time.Second),
+		CDN:           detectCDN(h),
+		CacheStatus:   cdnCacheStatus(h),
+		ServerTiming:  parseServerTiming(h),
+	}
+	if seconds, err := strconv.ParseInt(h.Get("Age"), 10, 64); err == nil && seconds >= 0 {
+		a.AgeSeconds = seconds
+	}
+	// A response with an age was served by a cache even if it does not say so
+	if a.CacheStatus == "" && a.AgeSeconds > 0 {
+		a.CacheStatus = "HIT"
+	}
+
+	hasValidator := a.ETag != "" || a.LastModified != ""
+	switch {
+	case cc.has("no-store"):
+		a.Cacheability = domain.CacheabilityNoStore
+	case cc.has("private"):
+		a.Cacheability = domain.CacheabilityPrivate
+	case containsFold(a.Vary, "*"):
+		a.Cacheability = domain.CacheabilityUncacheable
+	case lifetime > 0:
+		a.Cacheability = domain.CacheabilityPublic
+		a.Cacheable = true
+	case cc.has("no-cache") || cc.has("max-age") || cc.has("s-maxage") || hasValidator:
+		a.Cacheability = domain.CacheabilityRevalidate
+	default:
+		a.Cacheability = domain.CacheabilityUnspecified
+	}
+	return a
+}
+
+// detectCDN returns the provider whose headers appear in the response
+func detectCDN(h http.Header) string {
+	for _, sig := range cdnSignatures {
+		value := h.Get(sig.header)
+		if value == "" {
+			continue
+		}
+		if sig.contains == "" || strings.Contains(strings.ToLower(value), sig.contains) {
+			return sig.provider
+		}
+	}
+	return ""
+}
+
+// cdnCacheStatus normalises the cache status reported by the CDN to values
+// such as HIT, MISS, EXPIRED or BYPASS
+func cdnCacheStatus(h http.Header) string {
+	for _, name := range cacheStatusHeaders {
+		if value := h.Get(name); value != "" {
+			return normalizeCacheStatus(value)
+		}
+	}
+	if value := h.Get("Cache-Status"); value != "" {
+		return rfc9211CacheStatus(value)
+	}
+	for _, metric := range parseServerTiming(h) {
+		if metric.Name == "cdn-cache" || metric.Name == "cfCacheStatus" {
+			return normalizeCacheStatus(metric.Description)
+		}
+	}
+	return ""
+}
+
+// normalizeCacheStatus reduces values like "Hit from cloudfront",
+// "TCP_MISS from a23-1-2-3" or "MISS, HIT" to a single status. When several
+// caches are listed the last one is the closest to the client.
+func normalizeCacheStatus(value string) string {
+	parts := strings.Split(value, ",")
+	status := strings.ToUpper(strings.TrimSpace(parts[len(parts)-1]))
+	switch {
+	case status == "":
+		return ""
+	case strings.Contains(status, "MISS"):
+		return "MISS"
+	case strings.Contains(status, "HIT"):
+		return "HIT"
+	}
+	if word, _, ok := strings.Cut(status, " "); ok {
+		return word
+	}
+	return status
+}
+
+// rfc9211CacheStatus reads the Cache-Status header defined in RFC 9211, in
+// which the last listed cache is the closest to the client
+func rfc9211CacheStatus(value string) string {
+	entries := strings.Split(value, ",")
+	params := strings.Split(entries[len(entries)-1], ";")
+	for _, param := range params[1:] {
+		name, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+		switch strings.ToLower(name) {
+		case "hit":
+			return "HIT"
+		case "fwd":
+			if val == "stale" {
+				return "STALE"
+			}
+			return "MISS"
+		}
+	}
+	return ""
+}
+
+// parseServerTiming parses the Server-Timing header
+func parseServerTiming(h http.Header) []domain.ServerTimingMetric {
+	var metrics []domain.ServerTimingMetric
+	for _, header := range h.Values("Server-Timing") {
+		for _, entry := range strings.Split(header, ",") {
+			params := strings.Split(entry, ";")
+			metric := domain.ServerTimingMetric{Name: strings.TrimSpace(params[0])}
+			if metric.Name == "" {
+				continue
+			}
+			for _, param := range params[1:] {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				value = strings.Trim(value, `"`)
+				switch strings.ToLower(name) {
+				case "dur":
+					metric.DurationMs, _ = strconv.ParseFloat(value, 64)
+				case "desc":
+					metric.Description = value
+				}
+			}
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics
+}
+
+// headerList splits a comma separated header into its trimmed values
+func headerList(h http.Header, name string) []string {
+	var values []string
+	for _, header := range h.Values(name) {
+		for _, v := range strings.Split(header, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+func TestAnalyzeCaching(t *testing.T) {
+	tests := []struct {
+		name                 string
+		header               http.Header
+		expectedCacheability string
+		expectedCDN          string
+		expectedStatus       string
+		expectedMaxAge       int64
+	}{
+		{
+			name: "Cloudflare edge hit",
+			header: http.Header{
+				"Cache-Control":   {"public, max-age=3600"},
+				"Cf-Cache-Status": {"HIT"},
+				"Cf-Ray":          {"8a1b2c3d4e5f-AMS"},
+				"Age":             {"120"},
+			},
+			expectedCacheability: domain.CacheabilityPublic,
+			expectedCDN:          "Cloudflare",
+			expectedStatus:       "HIT",
+			expectedMaxAge:       3600,
+		},
+		{
+			name: "CloudFront miss",
+			header: http.Header{
+				"Cache-Control": {"s-maxage=600, max-age=0"},
+				"X-Cache":       {"Miss from cloudfront"},
+				"Via":           {"1.1 abc.cloudfront.net (CloudFront)"},
+				"X-Amz-Cf-Id":   {"abc=="},
+			},
+			expectedCacheability: domain.CacheabilityPublic,
+			expectedCDN:          "Amazon CloudFront",
+			expectedStatus:       "MISS",
+			expectedMaxAge:       600,
+		},
+		{
+			name: "Fastly shield miss and edge hit",
+			header: http.Header{
+				"Cache-Control": {"max-age=60"},
+				"X-Served-By":   {"cache-iad-1, cache-ams-2"},
+				"X-Cache":       {"MISS, HIT"},
+			},
+			expectedCacheability: domain.CacheabilityPublic,
+			expectedCDN:          "Fastly",
+			expectedStatus:       "HIT",
+			expectedMaxAge:       60,
+		},
+		{
+			name: "Akamai status from Server-Timing",
+			header: http.Header{
+				"Server":        {"AkamaiGHost"},
+				"Cache-Control": {"no-cache"},
+				"ETag":          {`"v1"`},
+				"Server-Timing": {"cdn-cache; desc=MISS, edge; dur=12, origin; dur=80.5"},
+			},
+			expectedCacheability: domain.CacheabilityRevalidate,
+			expectedCDN:          "Akamai",
+			expectedStatus:       "MISS",
+		},
+		{
+			name: "RFC 9211 Cache-Status",
+			header: http.Header{
+				"Cache-Control": {"max-age=30"},
+				"Cache-Status":  {"OriginCache; fwd=uri-miss, EdgeCache; hit; ttl=20"},
+			},
+			expectedCacheability: domain.CacheabilityPublic,
+			expectedStatus:       "HIT",
+			expectedMaxAge:       30,
+		},
+		{
+			name:                 "Private page",
+			header:               http.Header{"Cache-Control": {"private, max-age=600"}},
+			expectedCacheability: domain.CacheabilityPrivate,
+			expectedMaxAge:       600,
+		},
+		{
+			name:                 "No store",
+			header:               http.Header{"Cache-Control": {"no-store"}, "X-Vercel-Cache": {"BYPASS"}},
+			expectedCacheability: domain.CacheabilityNoStore,
+			expectedCDN:          "Vercel",
+			expectedStatus:       "BYPASS",
+		},
+		{
+			name:                 "Vary star",
+			header:               http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"Accept-Encoding, *"}},
+			expectedCacheability: domain.CacheabilityUncacheable,
+			expectedMaxAge:       60,
+		},
+		{
+			name:                 "Age implies a cache hit",
+			header:               http.Header{"Age": {"42"}, "Via": {"1.1 varnish (Varnish/7.4)"}},
+			expectedCacheability: domain.CacheabilityUnspecified,
+			expectedCDN:          "Varnish",
+			expectedStatus:       "HIT",
+		},
+		{
+			name:                 "No caching headers",
+			header:               http.Header{},
+			expectedCacheability: domain.CacheabilityUnspecified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := analyzeCaching(tt.header)
+			assert.Equal(t, tt.expectedCacheability, a.Cacheability)
+			assert.Equal(t, tt.expectedCacheability == domain.CacheabilityPublic, a.Cacheable)
+			assert.Equal(t, tt.expectedCDN, a.CDN)
+			assert.Equal(t, tt.expectedStatus, a.CacheStatus)
+			assert.Equal(t, tt.expectedMaxAge, a.MaxAgeSeconds)
+		})
+	}
+}
+
+func TestParseServerTiming(t *testing.T) {
+	h := http.Header{"Server-Timing": {`db;dur=53.2;desc="Database", cache;desc=hit`, "total;dur=123"}}
+
+	assert.Equal(t, []domain.ServerTimingMetric{
+		{Name: "db", DurationMs: 53.2, Description: "Database"},
+		{Name: "cache", Description: "hit"},
+		{Name: "total", DurationMs: 123},
+	}, parseServerTiming(h))
+}
+
+func TestHTTPClient_FetchPageCaching(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.Header().Set("Vary", "Accept-Encoding")
+		w.Header().Set("CF-Cache-Status", "EXPIRED")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop())
+	result, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CacheabilityPublic, result.Caching.Cacheability)
+	assert.Equal(t, "Cloudflare", result.Caching.CDN)
+	assert.Equal(t, "EXPIRED", result.Caching.CacheStatus)
+	assert.Equal(t, []string{"Accept-Encoding"}, result.Caching.Vary)
+}
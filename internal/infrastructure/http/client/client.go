@@ -2,86 +2,522 @@ package http
 
 import (
 	"context"
-	"io"
+	"errors"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/zap"
 )
 
+// DefaultMaxRedirects is the number of redirects followed when neither the
+// client nor the request specifies a limit
+const DefaultMaxRedirects = 10
+
 type client struct {
-	httpClient *http.Client
-	logger     *zap.Logger
+	httpClient   *http.Client
+	timeout      atomic.Int64
+	maxRedirects atomic.Int64
+	maxBodySize  int64
+	politeness   PolitenessPolicy
+	limits       *politeness
+	proxyURL     *url.URL
+	noProxy      string
+	proxies      []string
+	selector     *proxySelector
+	egress       atomic.Pointer[hostAllowList]
+	cache        *responseCache
+	logger       *zap.Logger
+}
+
+// fetched holds the final response of a request along with how it was reached
+type fetched struct {
+	resp      *http.Response
+	redirects domain.RedirectAnalysis
+	trace     *fetchTrace
+	start     time.Time
+}
+
+// Option configures optional behaviour of the HTTP client
+type Option func(*client)
+
+// WithMaxRedirects sets the default number of redirects followed per request
+func WithMaxRedirects(n int) Option {
+	return func(c *client) {
+		c.maxRedirects.Store(int64(n))
+	}
+}
+
+// WithMaxBodySize limits how many decoded bytes of a page are read
+func WithMaxBodySize(n int64) Option {
+	return func(c *client) {
+		c.maxBodySize = n
+	}
+}
+
+// WithPoliteness limits the request rate and concurrency per host
+func WithPoliteness(policy PolitenessPolicy) Option {
+	return func(c *client) {
+		c.politeness = policy
+	}
+}
+
+// WithProxy sends requests through proxyURL except for hosts matching the
+// comma separated noProxy rules, which use the same syntax as NO_PROXY
+func WithProxy(proxyURL *url.URL, noProxy string) Option {
+	return func(c *client) {
+		c.proxyURL = proxyURL
+		c.noProxy = noProxy
+	}
+}
+
+// WithAllowedProxies lets requests name one of proxies in their proxy option.
+// Other proxies are rejected, and so is bypassing a proxy set with WithProxy.
+func WithAllowedProxies(proxies []string) Option {
+	return func(c *client) {
+		c.proxies = proxies
+	}
 }
 
-func NewHTTPClient(timeout time.Duration, logger *zap.Logger) *client {
-	return &client{
+// WithAllowedHosts only lets requests, including redirects and link checks,
+// reach the listed hosts and their subdomains
+func WithAllowedHosts(hosts []string) Option {
+	return func(c *client) {
+		c.egress.Store(newHostAllowList(hosts))
+	}
+}
+
+// WithCache keeps up to maxEntries pages, and at most maxBytes of page content
+// when maxBytes is positive, in an in-memory response cache. Stale pages are
+// revalidated with conditional requests.
+func WithCache(maxEntries int, maxBytes int64) Option {
+	return func(c *client) {
+		c.cache = newResponseCache(maxEntries, maxBytes)
+	}
+}
+
+func NewHTTPClient(timeout time.Duration, logger *zap.Logger, opts ...Option) *client {
+	c := &client{
+		// The timeout is applied through the request context so that it can be
+		// overridden per request
 		httpClient: &http.Client{
-			Timeout: timeout,
+			// Redirects are followed manually so that every hop can be recorded
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				if len(via) >= 10 {
-					return domain.ErrTimeout
-				}
-				return nil
+				return http.ErrUseLastResponse
 			},
 		},
-		logger: logger,
+		maxBodySize: DefaultMaxBodySize,
+		logger:      logger,
 	}
+	c.timeout.Store(int64(timeout))
+	c.maxRedirects.Store(DefaultMaxRedirects)
+	c.egress.Store(newHostAllowList(nil))
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	c.selector = newProxySelector(c.proxyURL, c.noProxy, c.proxies)
+	transport.Proxy = c.selector.proxy
+	transport.OnProxyConnectResponse = checkProxyConnect
+	c.httpClient.Transport = transport
+	c.limits = newPoliteness(c.politeness, transport, c.allowDestination, logger)
+
+	return c
 }
 
-func (c *client) FetchPage(ctx context.Context, url string) (string, error) {
-	c.logger.Info("creating HTTP request", zap.String("url", url))
+// SetTimeout replaces the default timeout of subsequent requests
+func (c *client) SetTimeout(timeout time.Duration) {
+	c.timeout.Store(int64(timeout))
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		c.logger.Error("failed to create request", zap.Error(err))
-		return "", domain.ErrInvalidURL
+// SetMaxRedirects replaces the default redirect limit of subsequent requests
+func (c *client) SetMaxRedirects(n int) {
+	c.maxRedirects.Store(int64(n))
+}
+
+// SetAllowedHosts replaces the egress allow-list of subsequent requests
+func (c *client) SetAllowedHosts(hosts []string) {
+	c.egress.Store(newHostAllowList(hosts))
+}
+
+func (c *client) FetchPage(ctx context.Context, url string, opts domain.FetchOptions) (*domain.FetchResult, error) {
+	if err := c.selector.allow(opts.Proxy); err != nil {
+		return nil, err
+	}
+	// Checked before the cache too, so pages of hosts removed from the
+	// allow-list are not served
+	if err := c.allowDestination(url); err != nil {
+		return nil, err
 	}
 
-	// Set user agent to avoid being blocked
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; WebAnalyzer/1.0)")
+	timeout := time.Duration(c.timeout.Load())
+	if opts.TimeoutMs > 0 {
+		timeout = time.Duration(opts.TimeoutMs) * time.Millisecond
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
-	c.logger.Info("sending HTTP request")
-	resp, err := c.httpClient.Do(req)
+	key, cacheable := c.cache.key(url, opts)
+	var cached *cacheEntry
+	if cacheable {
+		if entry, ok := c.cache.get(key); ok {
+			if now := time.Now(); entry.fresh(now) {
+				c.log(ctx).Info("page served from cache",
+					zap.String("url", url),
+					zap.Duration("age", entry.age(now)))
+				result := entry.served(domain.CacheHit, now)
+				// Nothing was sent, so only the sizes of the cached page apply
+				result.Performance = domain.PerformanceMetrics{
+					CompressedSize:   result.Performance.CompressedSize,
+					UncompressedSize: result.Performance.UncompressedSize,
+					ContentEncoding:  result.Performance.ContentEncoding,
+					CompressionRatio: result.Performance.CompressionRatio,
+				}
+				return result, nil
+			}
+			cached = &entry
+			ctx = entry.withValidators(ctx)
+		}
+	}
+
+	c.log(ctx).Info("sending HTTP request",
+		zap.String("url", url),
+		zap.Duration("timeout", timeout),
+		zap.Bool("conditional", cached != nil))
+
+	f, err := c.follow(ctx, http.MethodGet, url, opts)
 	if err != nil {
-		return "", domain.ErrPageNotAccessible
+		return nil, err
 	}
+	resp := f.resp
 	defer resp.Body.Close()
 
 	// Check status code
 	switch resp.StatusCode {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return "", domain.ErrPageNotFound
+		return nil, domain.ErrPageNotFound
+	case http.StatusProxyAuthRequired:
+		// Plain HTTP requests are forwarded by the proxy, which answers itself
+		// when the credentials are wrong
+		return nil, domain.ErrProxyAuthRequired
+	case http.StatusNotModified:
+		if cached != nil {
+			return c.revalidated(ctx, url, *cached, f), nil
+		}
+		fallthrough
 	default:
-		return "", &domain.APIError{
-			StatusCode:  resp.StatusCode,
-			Message:     resp.Status,
-			Description: "Failed to fetch the page",
+		upstreamErr := domain.NewUpstreamError(resp.StatusCode, resp.Status)
+		upstreamErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, upstreamErr
+	}
+
+	if err := checkDeclaredContentType(resp); err != nil {
+		return nil, err
+	}
+
+	limit := c.maxBodySize
+	if opts.MaxBodyBytes > 0 && opts.MaxBodyBytes < limit {
+		limit = opts.MaxBodyBytes
+	}
+
+	b, err := readBody(resp, limit, opts.AllowTruncated)
+	if err != nil {
+		if errors.Is(err, domain.ErrPageTooLarge) {
+			c.log(ctx).Warn("page exceeds maximum body size",
+				zap.String("url", url),
+				zap.Int64("limit", limit),
+				zap.Int64("content_length", resp.ContentLength))
 		}
+		return nil, err
 	}
+	done := time.Now()
 
-	// Read body
-	body, err := io.ReadAll(resp.Body)
+	html, content, err := decodeHTML(resp, b.data)
 	if err != nil {
-		return "", domain.ErrInternalServer
+		return nil, err
+	}
+
+	performance := f.trace.metrics(done)
+	performance.TotalMs = milliseconds(f.start, done)
+	performance.CompressedSize = b.compressedSize
+	performance.UncompressedSize = int64(len(b.data))
+	performance.ContentEncoding = b.encoding
+	if b.compressedSize > 0 {
+		performance.CompressionRatio = float64(performance.UncompressedSize) / float64(b.compressedSize)
+	}
+
+	c.log(ctx).Info("page fetched",
+		zap.String("url", url),
+		zap.Float64("ttfb_ms", performance.TimeToFirstByteMs),
+		zap.Float64("total_ms", performance.TotalMs),
+		zap.Int64("size", performance.UncompressedSize),
+		zap.String("content_encoding", performance.ContentEncoding),
+		zap.Bool("truncated", b.truncated),
+		zap.String("charset", content.Charset),
+		zap.String("charset_source", content.CharsetSource))
+
+	result := &domain.FetchResult{
+		Body:        html,
+		StatusCode:  resp.StatusCode,
+		Redirects:   f.redirects,
+		Performance: performance,
+		Content:     content,
+		Caching:     analyzeCaching(resp.Header),
+		Truncated:   b.truncated,
+	}
+	switch {
+	case cacheable:
+		result.Cache = c.cache.store(key, resp, result)
+	case c.cache != nil:
+		result.Cache = &domain.CacheInfo{Status: domain.CacheBypass}
+	}
+
+	return result, nil
+}
+
+// revalidated returns the cached page after the site answered a conditional
+// request with 304 Not Modified
+func (c *client) revalidated(ctx context.Context, url string, entry cacheEntry, f *fetched) *domain.FetchResult {
+	done := time.Now()
+	entry = c.cache.refresh(entry, f.resp)
+
+	result := entry.served(domain.CacheRevalidated, done)
+	result.Redirects = f.redirects
+	// Sizes describe the cached page, timings the revalidation request
+	performance := f.trace.metrics(done)
+	performance.TotalMs = milliseconds(f.start, done)
+	performance.CompressedSize = result.Performance.CompressedSize
+	performance.UncompressedSize = result.Performance.UncompressedSize
+	performance.ContentEncoding = result.Performance.ContentEncoding
+	performance.CompressionRatio = result.Performance.CompressionRatio
+	result.Performance = performance
+
+	c.log(ctx).Info("cached page revalidated",
+		zap.String("url", url),
+		zap.Float64("total_ms", performance.TotalMs))
+
+	return result
+}
+
+// CheckLink probes a link with HEAD and falls back to a ranged GET when the
+// server does not handle HEAD properly
+func (c *client) CheckLink(ctx context.Context, url string) domain.LinkCheckResult {
+	if timeout := time.Duration(c.timeout.Load()); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	return string(body), nil
+	result := c.probe(ctx, http.MethodHead, url, domain.FetchOptions{})
+	if headUnsupported(result.StatusCode) {
+		// Only the first byte is requested so large resources are not downloaded
+		result = c.probe(ctx, http.MethodGet, url, domain.FetchOptions{
+			Headers: map[string]string{"Range": "bytes=0-0"},
+		})
+	}
+
+	c.log(ctx).Debug("link checked",
+		zap.String("url", url),
+		zap.String("status", result.Status),
+		zap.Int("status_code", result.StatusCode),
+		zap.String("method", result.Method))
+
+	return result
 }
 
-func (c *client) CheckLink(ctx context.Context, url string) bool {
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+// probe sends a single link check request and classifies its outcome
+func (c *client) probe(ctx context.Context, method, url string, opts domain.FetchOptions) domain.LinkCheckResult {
+	result := domain.LinkCheckResult{URL: url, Method: method}
+
+	f, err := c.follow(ctx, method, url, opts)
 	if err != nil {
+		result.Status = domain.LinkBroken
+		if errors.Is(err, domain.ErrDestinationNotAllowed) {
+			// The link may well work, it just may not be checked from here
+			result.Status = domain.LinkRestricted
+		}
+		var apiErr *domain.APIError
+		if errors.As(err, &apiErr) {
+			result.Error = apiErr.Code
+		}
+		return result
+	}
+	f.resp.Body.Close()
+
+	result.StatusCode = f.resp.StatusCode
+	result.FinalURL = f.redirects.FinalURL
+	result.Status = linkStatus(f.resp.StatusCode)
+	return result
+}
+
+// linkStatus classifies the final status code of a link check
+func linkStatus(statusCode int) string {
+	switch {
+	case statusCode >= 200 && statusCode < 400:
+		return domain.LinkReachable
+	case statusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The resource exists but is empty
+		return domain.LinkReachable
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return domain.LinkRestricted
+	default:
+		return domain.LinkBroken
+	}
+}
+
+// headUnsupported reports whether a HEAD response suggests the server rejects
+// HEAD requests rather than the resource itself
+func headUnsupported(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusMethodNotAllowed,
+		http.StatusNotImplemented:
+		return true
+	default:
 		return false
 	}
+}
+
+// HostLimits reports the politeness limiter state of recently contacted hosts
+func (c *client) HostLimits() []domain.HostLimitStats {
+	return c.limits.stats()
+}
+
+// follow issues the request and follows redirects up to the limit set in opts
+// or on the client, recording each hop. The caller is responsible for closing
+// the returned response body.
+func (c *client) follow(ctx context.Context, method, rawURL string, opts domain.FetchOptions) (*fetched, error) {
+	maxRedirects := int(c.maxRedirects.Load())
+	if opts.MaxRedirects != nil {
+		maxRedirects = *opts.MaxRedirects
+	}
+
+	f := &fetched{
+		redirects: domain.RedirectAnalysis{Chain: []domain.RedirectHop{}},
+		start:     time.Now(),
+	}
+	redirects := &f.redirects
+	visited := map[string]bool{}
 
-	resp, err := c.httpClient.Do(req)
+	current, err := url.Parse(rawURL)
 	if err != nil {
+		return nil, domain.ErrInvalidURL
+	}
+	origin := current
+	ctx = withProxyOverride(ctx, opts.Proxy)
+
+	for {
+		visited[current.String()] = true
+
+		if err := c.allowDestination(current.String()); err != nil {
+			c.log(ctx).Warn("request to a host outside the egress allow-list not sent",
+				zap.String("url", current.String()))
+			return nil, err
+		}
+
+		release, err := c.limits.acquire(ctx, current)
+		if err != nil {
+			c.log(ctx).Warn("request not sent while waiting for host limiter",
+				zap.String("url", current.String()),
+				zap.Error(err))
+			return nil, err
+		}
+
+		f.trace = newFetchTrace()
+		req, err := http.NewRequestWithContext(
+			httptrace.WithClientTrace(ctx, f.trace.clientTrace()), method, current.String(), nil)
+		if err != nil {
+			release()
+			c.log(ctx).Error("failed to create request", zap.Error(err))
+			return nil, domain.ErrInvalidURL
+		}
+
+		applyOptions(req, opts, sameOrigin(origin, current))
+		applyValidators(ctx, req)
+		if method == http.MethodGet {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		// Pass the caller's W3C trace context on to the fetched site
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			release()
+			apiErr := classifyError(err)
+			c.log(ctx).Error("HTTP request failed",
+				zap.String("url", current.String()),
+				zap.String("code", apiErr.Code),
+				zap.Error(err))
+			return nil, apiErr
+		}
+		latency := time.Since(f.trace.start)
+		// The host slot is held until the body has been consumed
+		resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+		location := resp.Header.Get("Location")
+		if !isRedirect(resp.StatusCode) || location == "" {
+			redirects.FinalURL = current.String()
+			f.resp = resp
+			return f, nil
+		}
+		resp.Body.Close()
+
+		next, err := current.Parse(location)
+		if err != nil {
+			return nil, domain.ErrInvalidURL
+		}
+
+		redirects.Chain = append(redirects.Chain, domain.RedirectHop{
+			URL:        current.String(),
+			StatusCode: resp.StatusCode,
+			Location:   next.String(),
+			LatencyMs:  float64(latency.Microseconds()) / 1000,
+		})
+
+		switch {
+		case current.Scheme == "http" && next.Scheme == "https":
+			redirects.HTTPSUpgrade = true
+		case current.Scheme == "https" && next.Scheme == "http":
+			redirects.HTTPSDowngrade = true
+			c.log(ctx).Warn("redirect downgrades HTTPS to HTTP",
+				zap.String("from", current.String()),
+				zap.String("to", next.String()))
+		}
+
+		if visited[next.String()] {
+			c.log(ctx).Error("redirect loop detected", zap.String("url", next.String()))
+			return nil, domain.ErrRedirectLoop
+		}
+		if len(redirects.Chain) > maxRedirects {
+			return nil, domain.ErrTooManyRedirects
+		}
+
+		current = next
+	}
+}
+
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	default:
 		return false
 	}
-	defer resp.Body.Close()
+}
 
-	return resp.StatusCode == http.StatusOK
+// log returns the request-scoped logger carried by ctx
+func (c *client) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, c.logger)
 }
@@ -1,15 +1,33 @@
 package http
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"errors"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/suraif16/webpage-analyzer/internal/core/domain"
 	"go.uber.org/zap"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
 )
 
 func TestHTTPClient_FetchPage(t *testing.T) {
@@ -43,7 +61,14 @@ func TestHTTPClient_FetchPage(t *testing.T) {
 			serverResponse: func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusInternalServerError)
 			},
-			expectedError: &domain.APIError{StatusCode: 500},
+			expectedError: &domain.APIError{StatusCode: 502, Code: domain.CodeUpstreamServerError, UpstreamStatus: 500},
+		},
+		{
+			name: "403 response",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expectedError: &domain.APIError{StatusCode: 502, Code: domain.CodeUpstreamClientError, UpstreamStatus: 403},
 		},
 	}
 
@@ -53,20 +78,877 @@ func TestHTTPClient_FetchPage(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
 			defer server.Close()
 
-			client := NewHTTPClient(5 * time.Second, logger)
-			content, err := client.FetchPage(context.Background(), server.URL)
+			client := NewHTTPClient(5*time.Second, logger)
+			page, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
 				if apiErr, ok := err.(*domain.APIError); ok {
-					assert.Equal(t, tt.expectedError.(*domain.APIError).StatusCode, apiErr.StatusCode)
+					expected := tt.expectedError.(*domain.APIError)
+					assert.Equal(t, expected.StatusCode, apiErr.StatusCode)
+					assert.Equal(t, expected.Code, apiErr.Code)
+					assert.Equal(t, expected.UpstreamStatus, apiErr.UpstreamStatus)
 				} else {
 					assert.Equal(t, tt.expectedError, err)
 				}
 			} else {
 				assert.NoError(t, err)
-				assert.Contains(t, content, "Hello")
+				assert.Contains(t, page.Body, "Hello")
+			}
+		})
+	}
+}
+
+func TestHTTPClient_FetchPageRedirects(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/middle", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/middle", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/final", http.StatusFound)
+	})
+	mux.HandleFunc("/final", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><body>Final</body></html>"))
+	})
+	mux.HandleFunc("/loop-a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop-b", http.StatusFound)
+	})
+	mux.HandleFunc("/loop-b", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop-a", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name          string
+		path          string
+		clientMax     int
+		opts          domain.FetchOptions
+		expectedError error
+		expectedHops  []string
+	}{
+		{
+			name:         "Records redirect chain",
+			path:         "/start",
+			clientMax:    10,
+			expectedHops: []string{"/start", "/middle"},
+		},
+		{
+			name:          "Detects redirect loop",
+			path:          "/loop-a",
+			clientMax:     10,
+			expectedError: domain.ErrRedirectLoop,
+		},
+		{
+			name:          "Client limit exceeded",
+			path:          "/start",
+			clientMax:     1,
+			expectedError: domain.ErrTooManyRedirects,
+		},
+		{
+			name:         "Per-request limit overrides client limit",
+			path:         "/start",
+			clientMax:    1,
+			opts:         domain.FetchOptions{MaxRedirects: intPtr(2)},
+			expectedHops: []string{"/start", "/middle"},
+		},
+		{
+			name:          "Per-request limit of zero disables redirects",
+			path:          "/start",
+			clientMax:     10,
+			opts:          domain.FetchOptions{MaxRedirects: intPtr(0)},
+			expectedError: domain.ErrTooManyRedirects,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewHTTPClient(5*time.Second, logger, WithMaxRedirects(tt.clientMax))
+			page, err := client.FetchPage(context.Background(), server.URL+tt.path, tt.opts)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Contains(t, page.Body, "Final")
+			assert.Equal(t, server.URL+"/final", page.Redirects.FinalURL)
+			assert.Len(t, page.Redirects.Chain, len(tt.expectedHops))
+			for i, hop := range page.Redirects.Chain {
+				assert.Equal(t, server.URL+tt.expectedHops[i], hop.URL)
+				assert.NotEmpty(t, hop.Location)
+				assert.GreaterOrEqual(t, hop.LatencyMs, 0.0)
+			}
+			assert.Equal(t, http.StatusMovedPermanently, page.Redirects.Chain[0].StatusCode)
+			assert.False(t, page.Redirects.HTTPSDowngrade)
+		})
+	}
+}
+
+func TestHTTPClient_FetchPageHTTPSDowngrade(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html></html>"))
+	}))
+	defer plain.Close()
+
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL, http.StatusFound)
+	}))
+	defer secure.Close()
+
+	client := NewHTTPClient(5*time.Second, logger)
+	client.httpClient.Transport = secure.Client().Transport
+
+	page, err := client.FetchPage(context.Background(), secure.URL, domain.FetchOptions{})
+	assert.NoError(t, err)
+	assert.True(t, page.Redirects.HTTPSDowngrade)
+	assert.False(t, page.Redirects.HTTPSUpgrade)
+	assert.Equal(t, plain.URL, page.Redirects.FinalURL)
+}
+
+func TestHTTPClient_FetchPagePerformance(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	html := bytes.Repeat([]byte("<p>compressible content</p>"), 200)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write(html)
+	gz.Close()
+
+	tests := []struct {
+		name           string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		compressed     int64
+		tls            bool
+	}{
+		{
+			name: "Uncompressed response",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.Write(html)
+			},
+			compressed: int64(len(html)),
+		},
+		{
+			name: "Gzip response",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, acceptEncoding, r.Header.Get("Accept-Encoding"))
+				w.Header().Set("Content-Type", "text/html")
+				w.Header().Set("Content-Encoding", "gzip")
+				w.Write(compressed.Bytes())
+			},
+			compressed: int64(compressed.Len()),
+		},
+		{
+			name: "TLS response",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.Write(html)
+			},
+			compressed: int64(len(html)),
+			tls:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewHTTPClient(5*time.Second, logger)
+
+			var server *httptest.Server
+			if tt.tls {
+				server = httptest.NewTLSServer(http.HandlerFunc(tt.serverResponse))
+				client.httpClient.Transport = server.Client().Transport
+			} else {
+				server = httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			}
+			defer server.Close()
+
+			page, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, string(html), page.Body)
+
+			perf := page.Performance
+			assert.Equal(t, tt.compressed, perf.CompressedSize)
+			assert.Equal(t, int64(len(html)), perf.UncompressedSize)
+			assert.Greater(t, perf.TCPConnectMs, 0.0)
+			assert.Greater(t, perf.TimeToFirstByteMs, 0.0)
+			assert.GreaterOrEqual(t, perf.TotalMs, perf.TimeToFirstByteMs)
+			if tt.tls {
+				assert.Greater(t, perf.TLSHandshakeMs, 0.0)
+			} else {
+				assert.Zero(t, perf.TLSHandshakeMs)
+			}
+		})
+	}
+}
+
+// TestHTTPClient_FetchPageDualStack dials IPv4 and IPv6 in parallel like happy
+// eyeballs does, so trace hooks run concurrently and the losing dial reports
+// after the round trip. Run with -race.
+func TestHTTPClient_FetchPageDualStack(t *testing.T) {
+	v4, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("IPv4 loopback unavailable:", err)
+	}
+	port := v4.Addr().(*net.TCPAddr).Port
+	v6, err := net.Listen("tcp6", net.JoinHostPort("::1", strconv.Itoa(port)))
+	if err != nil {
+		v4.Close()
+		t.Skip("IPv6 loopback unavailable:", err)
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><body>dual stack</body></html>"))
+	})
+	for _, l := range []net.Listener{v4, v6} {
+		server := &http.Server{Handler: handler}
+		go server.Serve(l)
+		defer server.Close()
+	}
+
+	var dialer net.Dialer
+	client := NewHTTPClient(5*time.Second, zap.NewNop())
+	client.httpClient.Transport = &http.Transport{
+		DisableKeepAlives: true,
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			conns := make(chan net.Conn, 2)
+			for _, addr := range []string{v4.Addr().String(), v6.Addr().String()} {
+				go func() {
+					conn, err := dialer.DialContext(ctx, network, addr)
+					if err != nil {
+						conn = nil
+					}
+					conns <- conn
+				}()
+			}
+			// The first connection wins; the other is closed whenever it completes
+			winner := <-conns
+			if winner == nil {
+				winner = <-conns
+			} else {
+				go func() {
+					if loser := <-conns; loser != nil {
+						loser.Close()
+					}
+				}()
+			}
+			if winner == nil {
+				return nil, errors.New("both dials failed")
+			}
+			return winner, nil
+		},
+	}
+
+	for i := 0; i < 20; i++ {
+		page, err := client.FetchPage(context.Background(), "http://localhost:"+strconv.Itoa(port), domain.FetchOptions{})
+		assert.NoError(t, err)
+		if assert.NotNil(t, page) {
+			assert.GreaterOrEqual(t, page.Performance.TCPConnectMs, 0.0)
+			assert.Greater(t, page.Performance.TimeToFirstByteMs, 0.0)
+		}
+	}
+}
+
+func TestHTTPClient_FetchPageTransportErrors(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	// A listener that is closed immediately gives an address nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	refusedURL := "http://" + listener.Addr().String()
+	listener.Close()
+
+	untrusted := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer untrusted.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	tests := []struct {
+		name         string
+		url          string
+		timeout      time.Duration
+		expectedCode string
+	}{
+		{name: "Connection refused", url: refusedURL, timeout: 5 * time.Second, expectedCode: domain.CodeConnectionRefused},
+		{name: "Untrusted certificate", url: untrusted.URL, timeout: 5 * time.Second, expectedCode: domain.CodeTLSError},
+		{name: "Timeout", url: slow.URL, timeout: 50 * time.Millisecond, expectedCode: domain.CodeTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewHTTPClient(tt.timeout, logger)
+			_, err := client.FetchPage(context.Background(), tt.url, domain.FetchOptions{})
+
+			var apiErr *domain.APIError
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.expectedCode, apiErr.Code)
+			assert.NotNil(t, errors.Unwrap(err), "cause should be preserved")
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected *domain.APIError
+	}{
+		{
+			name:     "DNS failure",
+			err:      &url.Error{Op: "Get", URL: "http://example.invalid", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}}},
+			expected: domain.ErrDNSResolutionFailed,
+		},
+		{
+			name:     "DNS timeout",
+			err:      &net.DNSError{Err: "i/o timeout", IsTimeout: true},
+			expected: domain.ErrTimeout,
+		},
+		{
+			name:     "Context deadline",
+			err:      &url.Error{Op: "Get", URL: "http://example.com", Err: context.DeadlineExceeded},
+			expected: domain.ErrTimeout,
+		},
+		{
+			name:     "Connection reset",
+			err:      &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)},
+			expected: domain.ErrConnectionReset,
+		},
+		{
+			name:     "Domain error passes through",
+			err:      domain.ErrRedirectLoop,
+			expected: domain.ErrRedirectLoop,
+		},
+		{
+			name:     "Unknown error",
+			err:      errors.New("boom"),
+			expected: domain.ErrPageNotAccessible,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyError(tt.err)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, tt.expected.StatusCode, err.StatusCode)
+		})
+	}
+}
+
+func TestHTTPClient_FetchPageOptions(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	var received []*http.Request
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r)
+		w.Write([]byte("<html></html>"))
+	}))
+	defer target.Close()
+
+	// Redirecting to "localhost" moves the request to a different host name
+	crossHost := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r)
+		if r.URL.Path == "/cross" {
+			http.Redirect(w, r, crossHost, http.StatusFound)
+			return
+		}
+		w.Write([]byte("<html></html>"))
+	}))
+	defer origin.Close()
+
+	tests := []struct {
+		name   string
+		path   string
+		opts   domain.FetchOptions
+		verify func(t *testing.T, reqs []*http.Request)
+	}{
+		{
+			name: "Custom headers, user agent and language",
+			opts: domain.FetchOptions{
+				Headers:        map[string]string{"X-Preview": "1", "User-Agent": "ignored"},
+				UserAgent:      "StagingBot/2.0",
+				AcceptLanguage: "de-DE",
+			},
+			verify: func(t *testing.T, reqs []*http.Request) {
+				assert.Equal(t, "1", reqs[0].Header.Get("X-Preview"))
+				assert.Equal(t, "StagingBot/2.0", reqs[0].UserAgent())
+				assert.Equal(t, "de-DE", reqs[0].Header.Get("Accept-Language"))
+			},
+		},
+		{
+			name: "Default user agent",
+			verify: func(t *testing.T, reqs []*http.Request) {
+				assert.Equal(t, defaultUserAgent, reqs[0].UserAgent())
+			},
+		},
+		{
+			name: "Cookies and basic auth",
+			opts: domain.FetchOptions{
+				Cookies: map[string]string{"session": "abc"},
+				Auth:    &domain.FetchAuth{Type: "basic", Username: "user", Password: "pass"},
+			},
+			verify: func(t *testing.T, reqs []*http.Request) {
+				cookie, err := reqs[0].Cookie("session")
+				assert.NoError(t, err)
+				assert.Equal(t, "abc", cookie.Value)
+				user, pass, ok := reqs[0].BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "user", user)
+				assert.Equal(t, "pass", pass)
+			},
+		},
+		{
+			name: "Bearer auth",
+			opts: domain.FetchOptions{
+				Auth: &domain.FetchAuth{Type: "bearer", Token: "secret-token"},
+			},
+			verify: func(t *testing.T, reqs []*http.Request) {
+				assert.Equal(t, "Bearer secret-token", reqs[0].Header.Get("Authorization"))
+			},
+		},
+		{
+			name: "Credentials dropped on cross-host redirect",
+			path: "/cross",
+			opts: domain.FetchOptions{
+				Headers: map[string]string{"X-Api-Key": "k", "X-Preview": "1"},
+				Cookies: map[string]string{"session": "abc"},
+				Auth:    &domain.FetchAuth{Type: "bearer", Token: "secret-token"},
+			},
+			verify: func(t *testing.T, reqs []*http.Request) {
+				assert.Len(t, reqs, 2)
+				assert.Equal(t, "Bearer secret-token", reqs[0].Header.Get("Authorization"))
+				assert.Empty(t, reqs[1].Header.Get("Authorization"))
+				assert.Empty(t, reqs[1].Header.Get("Cookie"))
+				assert.Empty(t, reqs[1].Header.Get("X-Api-Key"))
+				assert.Equal(t, "1", reqs[1].Header.Get("X-Preview"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			client := NewHTTPClient(5*time.Second, logger)
+			_, err := client.FetchPage(context.Background(), origin.URL+tt.path, tt.opts)
+			assert.NoError(t, err)
+			tt.verify(t, received)
+		})
+	}
+}
+
+func TestSameOrigin(t *testing.T) {
+	tests := []struct {
+		name     string
+		origin   string
+		target   string
+		expected bool
+	}{
+		{name: "Same host", origin: "https://example.com/a", target: "https://example.com/b", expected: true},
+		{name: "Upgrade to https", origin: "http://example.com", target: "https://example.com", expected: true},
+		{name: "Downgrade to http", origin: "https://example.com", target: "http://example.com"},
+		{name: "Other port", origin: "https://example.com", target: "https://example.com:8443", expected: true},
+		{name: "Other host", origin: "https://example.com", target: "https://cdn.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin, _ := url.Parse(tt.origin)
+			target, _ := url.Parse(tt.target)
+			assert.Equal(t, tt.expected, sameOrigin(origin, target))
+		})
+	}
+}
+
+func TestHTTPClient_FetchPageTimeoutOverride(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("<html></html>"))
+	}))
+	defer slow.Close()
+
+	client := NewHTTPClient(20*time.Millisecond, logger)
+
+	_, err := client.FetchPage(context.Background(), slow.URL, domain.FetchOptions{})
+	assert.ErrorIs(t, err, domain.ErrTimeout)
+
+	_, err = client.FetchPage(context.Background(), slow.URL, domain.FetchOptions{TimeoutMs: 2000})
+	assert.NoError(t, err)
+}
+
+func TestHTTPClient_FetchPageBodyLimits(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	page := append([]byte("<html>"), bytes.Repeat([]byte("a"), 994)...)
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write(page)
+	gz.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sized", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(page)
+	})
+	mux.HandleFunc("/endless", func(w http.ResponseWriter, r *http.Request) {
+		// Never sets Content-Length and keeps streaming until the client hangs up
+		for r.Context().Err() == nil {
+			if _, err := w.Write(page); err != nil {
+				return
+			}
+		}
+	})
+	mux.HandleFunc("/gzip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed.Bytes())
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		path              string
+		maxBodySize       int64
+		opts              domain.FetchOptions
+		expectedError     error
+		expectedSize      int
+		expectedTruncated bool
+	}{
+		{name: "Within limit", path: "/sized", maxBodySize: 1000, expectedSize: 1000},
+		{name: "Content-Length over limit", path: "/sized", maxBodySize: 999, expectedError: domain.ErrPageTooLarge},
+		{name: "Endless stream", path: "/endless", maxBodySize: 5000, expectedError: domain.ErrPageTooLarge},
+		{name: "Decoded size over limit", path: "/gzip", maxBodySize: 500, expectedError: domain.ErrPageTooLarge},
+		{
+			name:          "Per-request limit",
+			path:          "/sized",
+			maxBodySize:   5000,
+			opts:          domain.FetchOptions{MaxBodyBytes: 100},
+			expectedError: domain.ErrPageTooLarge,
+		},
+		{
+			name:          "Per-request limit cannot raise client limit",
+			path:          "/sized",
+			maxBodySize:   100,
+			opts:          domain.FetchOptions{MaxBodyBytes: 5000},
+			expectedError: domain.ErrPageTooLarge,
+		},
+		{
+			name:              "Truncated endless stream",
+			path:              "/endless",
+			maxBodySize:       5000,
+			opts:              domain.FetchOptions{MaxBodyBytes: 2500, AllowTruncated: true},
+			expectedSize:      2500,
+			expectedTruncated: true,
+		},
+		{
+			name:              "Truncated sized page",
+			path:              "/sized",
+			maxBodySize:       400,
+			opts:              domain.FetchOptions{AllowTruncated: true},
+			expectedSize:      400,
+			expectedTruncated: true,
+		},
+		{
+			name:         "Allow truncated on small page",
+			path:         "/sized",
+			maxBodySize:  5000,
+			opts:         domain.FetchOptions{AllowTruncated: true},
+			expectedSize: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewHTTPClient(5*time.Second, logger, WithMaxBodySize(tt.maxBodySize))
+			result, err := client.FetchPage(context.Background(), server.URL+tt.path, tt.opts)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Body, tt.expectedSize)
+			assert.Equal(t, tt.expectedTruncated, result.Truncated)
+		})
+	}
+}
+
+func TestHTTPClient_FetchPageContentEncoding(t *testing.T) {
+	page := "<html><body>" + strings.Repeat("<p>compressible</p>", 500) + "</body></html>"
+
+	compress := func(newWriter func(io.Writer) io.WriteCloser) []byte {
+		var buf bytes.Buffer
+		w := newWriter(&buf)
+		w.Write([]byte(page))
+		w.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name             string
+		encoding         string
+		body             []byte
+		expectedEncoding string
+		expectedError    error
+	}{
+		{
+			name:             "Identity",
+			body:             []byte(page),
+			expectedEncoding: "identity",
+		},
+		{
+			name:     "Gzip",
+			encoding: "gzip",
+			body: compress(func(w io.Writer) io.WriteCloser {
+				return gzip.NewWriter(w)
+			}),
+			expectedEncoding: "gzip",
+		},
+		{
+			name:     "Deflate",
+			encoding: "deflate",
+			body: compress(func(w io.Writer) io.WriteCloser {
+				return zlib.NewWriter(w)
+			}),
+			expectedEncoding: "deflate",
+		},
+		{
+			name:     "Raw deflate",
+			encoding: "deflate",
+			body: compress(func(w io.Writer) io.WriteCloser {
+				fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+				return fw
+			}),
+			expectedEncoding: "deflate",
+		},
+		{
+			name:     "Brotli",
+			encoding: "br",
+			body: compress(func(w io.Writer) io.WriteCloser {
+				return brotli.NewWriter(w)
+			}),
+			expectedEncoding: "br",
+		},
+		{
+			name:     "Zstandard",
+			encoding: "zstd",
+			body: compress(func(w io.Writer) io.WriteCloser {
+				zw, _ := zstd.NewWriter(w)
+				return zw
+			}),
+			expectedEncoding: "zstd",
+		},
+		{
+			name:          "Unknown encoding",
+			encoding:      "compress",
+			body:          []byte(page),
+			expectedError: domain.ErrUnsupportedContentEncoding,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, acceptEncoding, r.Header.Get("Accept-Encoding"))
+				w.Header().Set("Content-Type", "text/html")
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			client := NewHTTPClient(5*time.Second, zap.NewNop())
+			result, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, page, result.Body)
+			assert.Equal(t, tt.expectedEncoding, result.Performance.ContentEncoding)
+			assert.Equal(t, int64(len(tt.body)), result.Performance.CompressedSize)
+			assert.Equal(t, int64(len(page)), result.Performance.UncompressedSize)
+			assert.InDelta(t, float64(len(page))/float64(len(tt.body)), result.Performance.CompressionRatio, 0.001)
+		})
+	}
+}
+
+func TestHTTPClient_FetchPageContent(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	encode := func(enc encoding.Encoding, s string) []byte {
+		b, err := enc.NewEncoder().Bytes([]byte(s))
+		assert.NoError(t, err)
+		return b
+	}
+
+	tests := []struct {
+		name            string
+		contentType     string
+		body            []byte
+		expectedError   error
+		expectedBody    string
+		expectedType    string
+		expectedCharset string
+		expectedSource  string
+	}{
+		{
+			name:          "PDF rejected by header",
+			contentType:   "application/pdf",
+			body:          []byte("%PDF-1.7"),
+			expectedError: domain.ErrUnsupportedContentType,
+		},
+		{
+			name:          "Image rejected by sniffing",
+			contentType:   "application/octet-stream",
+			body:          []byte("\x89PNG\r\n\x1a\n0000"),
+			expectedError: domain.ErrUnsupportedContentType,
+		},
+		{
+			name:            "HTML sniffed from generic type",
+			contentType:     "application/octet-stream",
+			body:            []byte("<!DOCTYPE html><title>Hi</title>"),
+			expectedBody:    "<!DOCTYPE html><title>Hi</title>",
+			expectedType:    "text/html",
+			expectedCharset: "utf-8",
+			expectedSource:  "default",
+		},
+		{
+			name:            "Shift_JIS from header",
+			contentType:     "text/html; charset=Shift_JIS",
+			body:            encode(japanese.ShiftJIS, "<title>日本語</title>"),
+			expectedBody:    "<title>日本語</title>",
+			expectedType:    "text/html",
+			expectedCharset: "shift_jis",
+			expectedSource:  "header",
+		},
+		{
+			name:            "Windows-1252 from meta",
+			contentType:     "text/html",
+			body:            encode(charmap.Windows1252, `<meta charset="windows-1252"><title>Café</title>`),
+			expectedBody:    `<meta charset="windows-1252"><title>Café</title>`,
+			expectedType:    "text/html",
+			expectedCharset: "windows-1252",
+			expectedSource:  "meta",
+		},
+		{
+			name:            "http-equiv meta",
+			contentType:     "text/html",
+			body:            encode(charmap.ISO8859_1, `<meta http-equiv="Content-Type" content="text/html; charset=iso-8859-1"><title>Café</title>`),
+			expectedBody:    `<meta http-equiv="Content-Type" content="text/html; charset=iso-8859-1"><title>Café</title>`,
+			expectedType:    "text/html",
+			expectedCharset: "windows-1252",
+			expectedSource:  "meta",
+		},
+		{
+			name:            "UTF-16 from BOM",
+			contentType:     "text/html",
+			body:            encode(unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), "<title>Grüße</title>"),
+			expectedBody:    "<title>Grüße</title>",
+			expectedType:    "text/html",
+			expectedCharset: "utf-16le",
+			expectedSource:  "bom",
+		},
+		{
+			name:            "Header takes precedence over meta",
+			contentType:     "text/html; charset=utf-8",
+			body:            []byte(`<meta charset="windows-1252"><title>Café</title>`),
+			expectedBody:    `<meta charset="windows-1252"><title>Café</title>`,
+			expectedType:    "text/html",
+			expectedCharset: "utf-8",
+			expectedSource:  "header",
+		},
+		{
+			name:            "Empty body without type",
+			body:            []byte{},
+			expectedBody:    "",
+			expectedType:    "text/html",
+			expectedCharset: "utf-8",
+			expectedSource:  "default",
+		},
+		{
+			name:            "XHTML",
+			contentType:     "application/xhtml+xml",
+			body:            []byte("<html><title>X</title></html>"),
+			expectedBody:    "<html><title>X</title></html>",
+			expectedType:    "application/xhtml+xml",
+			expectedCharset: "utf-8",
+			expectedSource:  "default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			client := NewHTTPClient(5*time.Second, logger)
+			page, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
 			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, page.Body)
+			assert.Equal(t, tt.expectedType, page.Content.ContentType)
+			assert.Equal(t, tt.expectedCharset, page.Content.Charset)
+			assert.Equal(t, tt.expectedSource, page.Content.CharsetSource)
+		})
+	}
+}
+
+func TestDetectCharset(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{name: "UTF-8", data: []byte("<p>Grüße</p>"), expected: "utf-8"},
+		{name: "UTF-8 cut mid-rune", data: []byte("<p>Grüße")[:len("<p>Grü")-1], expected: "utf-8"},
+		{name: "UTF-8 cut in a three byte rune", data: []byte("<p>日本")[:len("<p>日本")-1], expected: "utf-8"},
+		{name: "Windows-1252", data: []byte("<p>Caf\xe9</p>"), expected: "windows-1252"},
+		{name: "Windows-1252 cut after a non-ASCII byte", data: []byte("<p>Caf\xe9 au lait"), expected: "windows-1252"},
+		{name: "Lead byte followed by ASCII", data: []byte("<p>Caf\xe9!"), expected: "windows-1252"},
+		{name: "Empty", data: []byte{}, expected: "utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, source := detectCharset("", tt.data)
+			assert.Equal(t, tt.expected, name)
+			assert.Equal(t, charsetSourceDefault, source)
 		})
 	}
 }
@@ -79,21 +961,110 @@ func TestHTTPClient_CheckLink(t *testing.T) {
 	tests := []struct {
 		name           string
 		serverResponse func(w http.ResponseWriter, r *http.Request)
-		expected       bool
+		expectedStatus string
+		expectedCode   int
+		expectedMethod string
 	}{
 		{
 			name: "Valid link",
 			serverResponse: func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
 			},
-			expected: true,
+			expectedStatus: domain.LinkReachable,
+			expectedCode:   http.StatusOK,
+			expectedMethod: http.MethodHead,
 		},
 		{
 			name: "Invalid link",
 			serverResponse: func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusNotFound)
 			},
-			expected: false,
+			expectedStatus: domain.LinkBroken,
+			expectedCode:   http.StatusNotFound,
+			expectedMethod: http.MethodHead,
+		},
+		{
+			name: "No content",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			expectedStatus: domain.LinkReachable,
+			expectedCode:   http.StatusNoContent,
+			expectedMethod: http.MethodHead,
+		},
+		{
+			name: "Redirect to valid page",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/" {
+					http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			},
+			expectedStatus: domain.LinkReachable,
+			expectedCode:   http.StatusOK,
+			expectedMethod: http.MethodHead,
+		},
+		{
+			name: "HEAD not allowed falls back to ranged GET",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				if r.Header.Get("Range") != "bytes=0-0" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusPartialContent)
+			},
+			expectedStatus: domain.LinkReachable,
+			expectedCode:   http.StatusPartialContent,
+			expectedMethod: http.MethodGet,
+		},
+		{
+			name: "Fallback GET still fails",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					w.WriteHeader(http.StatusNotImplemented)
+					return
+				}
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectedStatus: domain.LinkBroken,
+			expectedCode:   http.StatusInternalServerError,
+			expectedMethod: http.MethodGet,
+		},
+		{
+			name: "Unauthorized is restricted",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			expectedStatus: domain.LinkRestricted,
+			expectedCode:   http.StatusUnauthorized,
+			expectedMethod: http.MethodHead,
+		},
+		{
+			name: "Forbidden is restricted",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expectedStatus: domain.LinkRestricted,
+			expectedCode:   http.StatusForbidden,
+			expectedMethod: http.MethodGet,
+		},
+		{
+			name: "Empty resource",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			},
+			expectedStatus: domain.LinkReachable,
+			expectedCode:   http.StatusRequestedRangeNotSatisfiable,
+			expectedMethod: http.MethodGet,
 		},
 	}
 
@@ -102,9 +1073,25 @@ func TestHTTPClient_CheckLink(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
 			defer server.Close()
 
-			client := NewHTTPClient(5 * time.Second, logger)
+			client := NewHTTPClient(5*time.Second, logger)
 			result := client.CheckLink(context.Background(), server.URL)
-			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedCode, result.StatusCode)
+			assert.Equal(t, tt.expectedMethod, result.Method)
+			assert.Equal(t, server.URL, result.URL)
 		})
 	}
 }
+
+func TestHTTPClient_CheckLinkConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop())
+	result := client.CheckLink(context.Background(), serverURL)
+	assert.False(t, result.Reachable())
+	assert.Equal(t, domain.LinkBroken, result.Status)
+	assert.Equal(t, domain.ErrConnectionRefused.Code, result.Error)
+	assert.Zero(t, result.StatusCode)
+}
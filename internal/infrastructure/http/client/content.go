@@ -0,0 +1,157 @@
+package http
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"golang.org/x/net/html/charset"
+)
+
+// Charset sources reported in domain.ContentInfo, in order of precedence
+const (
+	charsetSourceHeader  = "header"
+	charsetSourceBOM     = "bom"
+	charsetSourceMeta    = "meta"
+	charsetSourceDefault = "default"
+)
+
+// metaCharset matches both <meta charset="x"> and the http-equiv form
+// <meta http-equiv="Content-Type" content="text/html; charset=x">
+var metaCharset = regexp.MustCompile(`(?i)<meta[^>]*?charset\s*=\s*["']?\s*([a-z0-9_:.\-]+)`)
+
+var boms = []struct {
+	bom      []byte
+	encoding string
+}{
+	{[]byte{0xEF, 0xBB, 0xBF}, "utf-8"},
+	{[]byte{0xFE, 0xFF}, "utf-16be"},
+	{[]byte{0xFF, 0xFE}, "utf-16le"},
+}
+
+// isHTMLMediaType reports whether the media type can be analyzed as HTML
+func isHTMLMediaType(mediaType string) bool {
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// declaredMediaType returns the media type and charset parameter of the
+// Content-Type header. Generic types that say nothing about the content are
+// reported as empty so that the body gets sniffed instead.
+func declaredMediaType(resp *http.Response) (string, string) {
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType == "application/octet-stream" {
+		return "", ""
+	}
+	return mediaType, params["charset"]
+}
+
+// checkDeclaredContentType rejects responses whose headers already show they
+// are not HTML, before any of the body is downloaded
+func checkDeclaredContentType(resp *http.Response) error {
+	mediaType, _ := declaredMediaType(resp)
+	if mediaType != "" && !isHTMLMediaType(mediaType) {
+		return unsupportedContentType(mediaType)
+	}
+	return nil
+}
+
+// decodeHTML verifies the body is HTML and transcodes it to UTF-8. The charset
+// is taken from the Content-Type header, then a byte order mark, then a
+// <meta> declaration, falling back to UTF-8 or Windows-1252.
+func decodeHTML(resp *http.Response, data []byte) (string, domain.ContentInfo, error) {
+	mediaType, headerCharset := declaredMediaType(resp)
+	if mediaType == "" && len(data) == 0 {
+		// An empty page is valid HTML with nothing in it, not plain text
+		mediaType = "text/html"
+	}
+	if mediaType == "" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+		if !isHTMLMediaType(mediaType) {
+			return "", domain.ContentInfo{}, unsupportedContentType(mediaType)
+		}
+	}
+
+	info := domain.ContentInfo{ContentType: mediaType}
+	name, source := detectCharset(headerCharset, data)
+	enc, canonical := charset.Lookup(name)
+	if enc == nil {
+		enc, canonical, source = nil, "utf-8", charsetSourceDefault
+	}
+	info.Charset = canonical
+	info.CharsetSource = source
+
+	// Strip a byte order mark so it does not end up in the title
+	for _, b := range boms {
+		if b.encoding == canonical && bytes.HasPrefix(data, b.bom) {
+			data = data[len(b.bom):]
+			break
+		}
+	}
+
+	if enc == nil || canonical == "utf-8" {
+		return strings.ToValidUTF8(string(data), "�"), info, nil
+	}
+
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", info, domain.ErrInternalServer.WithCause(err)
+	}
+	return string(decoded), info, nil
+}
+
+// detectCharset returns the charset label for data along with where it was found
+func detectCharset(headerCharset string, data []byte) (string, string) {
+	if headerCharset != "" {
+		if enc, _ := charset.Lookup(headerCharset); enc != nil {
+			return headerCharset, charsetSourceHeader
+		}
+	}
+
+	for _, b := range boms {
+		if bytes.HasPrefix(data, b.bom) {
+			return b.encoding, charsetSourceBOM
+		}
+	}
+
+	// Like browsers, only the start of the document is scanned for <meta>
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	if m := metaCharset.FindSubmatch(head); m != nil {
+		if enc, _ := charset.Lookup(string(m[1])); enc != nil {
+			return string(m[1]), charsetSourceMeta
+		}
+	}
+
+	if utf8.Valid(trimIncompleteRune(data)) {
+		return "utf-8", charsetSourceDefault
+	}
+	return "windows-1252", charsetSourceDefault
+}
+
+// trimIncompleteRune drops a multi-byte UTF-8 sequence cut off at the end of
+// data, as happens when a body is truncated at the size limit
+func trimIncompleteRune(data []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+		start := len(data) - i
+		if !utf8.RuneStart(data[start]) {
+			continue
+		}
+		if !utf8.FullRune(data[start:]) {
+			return data[:start]
+		}
+		break
+	}
+	return data
+}
+
+func unsupportedContentType(mediaType string) *domain.APIError {
+	err := *domain.ErrUnsupportedContentType
+	err.Description = "The URL returned " + mediaType + " content, only HTML pages can be analyzed."
+	return &err
+}
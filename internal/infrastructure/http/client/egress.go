@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// hostAllowList restricts the hosts requests may be sent to. An entry matches
+// the host itself and all of its subdomains; a leading "*." or "." is accepted
+// for readability. An empty list allows every host.
+type hostAllowList struct {
+	hosts []string
+}
+
+func newHostAllowList(hosts []string) *hostAllowList {
+	l := &hostAllowList{}
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		host = strings.TrimPrefix(strings.TrimPrefix(host, "*"), ".")
+		host = strings.Trim(host, "[]")
+		if host != "" {
+			l.hosts = append(l.hosts, host)
+		}
+	}
+	return l
+}
+
+// allows reports whether requests may be sent to host, given without a port
+func (l *hostAllowList) allows(host string) bool {
+	if len(l.hosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, allowed := range l.hosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowDestination rejects a URL whose host is outside the egress allow-list
+func (c *client) allowDestination(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return domain.ErrInvalidURL
+	}
+	if !c.egress.Load().allows(u.Hostname()) {
+		return domain.ErrDestinationNotAllowed
+	}
+	return nil
+}
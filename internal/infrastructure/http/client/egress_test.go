@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+func TestHostAllowList(t *testing.T) {
+	tests := []struct {
+		name     string
+		hosts    []string
+		host     string
+		expected bool
+	}{
+		{name: "Empty list", hosts: nil, host: "example.com", expected: true},
+		{name: "Exact host", hosts: []string{"example.com"}, host: "example.com", expected: true},
+		{name: "Subdomain", hosts: []string{"example.com"}, host: "www.example.com", expected: true},
+		{name: "Wildcard entry", hosts: []string{"*.example.com"}, host: "a.b.example.com", expected: true},
+		{name: "Case and trailing dot", hosts: []string{"Example.COM"}, host: "WWW.example.com.", expected: true},
+		{name: "Suffix without a dot", hosts: []string{"example.com"}, host: "badexample.com", expected: false},
+		{name: "Other host", hosts: []string{"example.com", "example.org"}, host: "example.net", expected: false},
+		{name: "IPv6 address", hosts: []string{"[::1]"}, host: "::1", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newHostAllowList(tt.hosts).allows(tt.host))
+		})
+	}
+}
+
+func TestHTTPClient_EgressAllowList(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/away" {
+			// localhost resolves to the same server under a host that is not allowed
+			http.Redirect(w, r, "http://"+strings.Replace(r.Host, "127.0.0.1", "localhost", 1)+"/page", http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(), WithAllowedHosts([]string{"127.0.0.1"}))
+	ctx := context.Background()
+
+	_, err := client.FetchPage(ctx, server.URL, domain.FetchOptions{})
+	assert.NoError(t, err)
+
+	// Redirects are checked before they are followed
+	_, err = client.FetchPage(ctx, server.URL+"/away", domain.FetchOptions{})
+	assert.ErrorIs(t, err, domain.ErrDestinationNotAllowed)
+	assert.Equal(t, int32(2), requests.Load())
+
+	link := client.CheckLink(ctx, strings.Replace(server.URL, "127.0.0.1", "localhost", 1))
+	assert.Equal(t, domain.LinkRestricted, link.Status)
+	assert.Equal(t, domain.ErrDestinationNotAllowed.Code, link.Error)
+
+	client.SetAllowedHosts([]string{"example.com"})
+	_, err = client.FetchPage(ctx, server.URL, domain.FetchOptions{})
+	assert.ErrorIs(t, err, domain.ErrDestinationNotAllowed)
+	assert.Equal(t, int32(2), requests.Load(), "requests outside the allow-list must not be sent")
+
+	client.SetAllowedHosts(nil)
+	_, err = client.FetchPage(ctx, server.URL+"/away", domain.FetchOptions{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// acceptEncoding lists the content codings FetchPage can decode. Setting it
+// explicitly disables the transport's transparent gzip handling, which lets
+// FetchPage report the bytes on the wire.
+const acceptEncoding = "gzip, deflate, br, zstd"
+
+// contentEncodings returns the codings applied to the response in the order
+// they were applied
+func contentEncodings(resp *http.Response) []string {
+	var codings []string
+	for _, header := range resp.Header.Values("Content-Encoding") {
+		for _, coding := range strings.Split(header, ",") {
+			coding = strings.ToLower(strings.TrimSpace(coding))
+			if coding != "" && coding != domain.EncodingIdentity {
+				codings = append(codings, coding)
+			}
+		}
+	}
+	return codings
+}
+
+// decodeContent wraps r with decoders that undo the given codings
+func decodeContent(r io.Reader, codings []string) (io.Reader, func(), error) {
+	var closers []func()
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+
+	// Codings are listed in the order they were applied, so undo them in reverse
+	for i := len(codings) - 1; i >= 0; i-- {
+		switch codings[i] {
+		case "gzip", "x-gzip":
+			gz, err := gzip.NewReader(r)
+			if err != nil {
+				closeAll()
+				return nil, nil, domain.ErrInternalServer.WithCause(err)
+			}
+			closers = append(closers, func() { gz.Close() })
+			r = gz
+		case "deflate":
+			d := newDeflateReader(r)
+			closers = append(closers, func() { d.Close() })
+			r = d
+		case "br":
+			r = brotli.NewReader(r)
+		case "zstd":
+			zr, err := zstd.NewReader(r)
+			if err != nil {
+				closeAll()
+				return nil, nil, domain.ErrInternalServer.WithCause(err)
+			}
+			closers = append(closers, zr.Close)
+			r = zr
+		default:
+			closeAll()
+			return nil, nil, unsupportedContentEncoding(codings[i])
+		}
+	}
+	return r, closeAll, nil
+}
+
+// newDeflateReader decodes "deflate" bodies, which should be zlib wrapped but
+// are sent as raw DEFLATE by some servers
+func newDeflateReader(r io.Reader) io.ReadCloser {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err == nil && isZlibHeader(header) {
+		if zr, err := zlib.NewReader(br); err == nil {
+			return zr
+		}
+	}
+	return flate.NewReader(br)
+}
+
+// isZlibHeader checks the CMF and FLG bytes of a zlib stream (RFC 1950)
+func isZlibHeader(b []byte) bool {
+	return b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
+
+func unsupportedContentEncoding(coding string) *domain.APIError {
+	err := *domain.ErrUnsupportedContentEncoding
+	err.Description = "The URL returned content encoded with " + coding + ", which cannot be decoded."
+	return &err
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// classifyError maps a transport error returned by http.Client.Do to the
+// matching domain error, keeping the original error as its cause
+func classifyError(err error) *domain.APIError {
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	var (
+		dnsErr      *net.DNSError
+		netErr      net.Error
+		certErr     *tls.CertificateVerificationError
+		recordErr   tls.RecordHeaderError
+		alertErr    tls.AlertError
+		unknownCA   x509.UnknownAuthorityError
+		hostnameErr x509.HostnameError
+		invalidCert x509.CertificateInvalidError
+		proxyErr    *proxyError
+	)
+
+	switch {
+	case errors.As(err, &proxyErr):
+		if proxyErr.statusCode == http.StatusProxyAuthRequired {
+			return domain.ErrProxyAuthRequired.WithCause(err)
+		}
+		return domain.ErrProxyFailed.WithCause(err)
+	case isProxyDialError(err):
+		return domain.ErrProxyFailed.WithCause(err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.ErrTimeout.WithCause(err)
+	case errors.As(err, &dnsErr):
+		if dnsErr.IsTimeout {
+			return domain.ErrTimeout.WithCause(err)
+		}
+		return domain.ErrDNSResolutionFailed.WithCause(err)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return domain.ErrConnectionRefused.WithCause(err)
+	case errors.Is(err, syscall.ECONNRESET):
+		return domain.ErrConnectionReset.WithCause(err)
+	case errors.As(err, &certErr),
+		errors.As(err, &recordErr),
+		errors.As(err, &alertErr),
+		errors.As(err, &unknownCA),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &invalidCert):
+		return domain.ErrTLSHandshakeFailed.WithCause(err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return domain.ErrTimeout.WithCause(err)
+	default:
+		return domain.ErrPageNotAccessible.WithCause(err)
+	}
+}
+
+// isProxyDialError reports whether err happened while connecting to the proxy
+// or negotiating with a SOCKS proxy, rather than talking to the target
+func isProxyDialError(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	return opErr.Op == "proxyconnect" || strings.HasPrefix(opErr.Op, "socks")
+}
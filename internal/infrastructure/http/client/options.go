@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+const defaultUserAgent = "Mozilla/5.0 (compatible; WebAnalyzer/1.0)"
+
+// applyOptions sets the caller supplied headers, cookies and credentials on req.
+// Credentials are only attached while the request stays on the original host
+// without being downgraded from https, mirroring what net/http does when it
+// follows redirects itself.
+func applyOptions(req *http.Request, opts domain.FetchOptions, sameHost bool) {
+	// Set user agent to avoid being blocked
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	for name, value := range opts.Headers {
+		if !sameHost && domain.IsSensitiveHeader(name) {
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	if opts.UserAgent != "" {
+		req.Header.Set("User-Agent", opts.UserAgent)
+	}
+	if opts.AcceptLanguage != "" {
+		req.Header.Set("Accept-Language", opts.AcceptLanguage)
+	}
+
+	if !sameHost {
+		return
+	}
+
+	for name, value := range opts.Cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+
+	if opts.Auth != nil {
+		switch strings.ToLower(opts.Auth.Type) {
+		case "basic":
+			req.SetBasicAuth(opts.Auth.Username, opts.Auth.Password)
+		case "bearer":
+			req.Header.Set("Authorization", "Bearer "+opts.Auth.Token)
+		}
+	}
+}
+
+// sameOrigin reports whether credentials given for the requested URL may be
+// sent to a redirect target: the host must match and an https request must
+// not have been downgraded to plain http
+func sameOrigin(origin, target *url.URL) bool {
+	if origin.Hostname() != target.Hostname() {
+		return false
+	}
+	return origin.Scheme != "https" || target.Scheme == "https"
+}
@@ -0,0 +1,349 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// robotsAgent is the user agent token matched against robots.txt groups
+	robotsAgent = "webanalyzer"
+	// robotsTimeout bounds how long a robots.txt lookup may delay the first request to a host
+	robotsTimeout = 5 * time.Second
+	// robotsMaxRedirects is the number of redirects followed to reach robots.txt (RFC 9309 section 2.3.1.2)
+	robotsMaxRedirects = 5
+	// hostIdleTTL is how long an unused host is remembered before its state is dropped
+	hostIdleTTL = 10 * time.Minute
+)
+
+// PolitenessPolicy limits how hard any single host is hit by outbound
+// requests. The limits are shared by every analysis running in the process.
+type PolitenessPolicy struct {
+	// RequestsPerSecond is the sustained request rate per host; 0 disables rate limiting
+	RequestsPerSecond float64
+	// Burst is the number of requests a host may receive back to back
+	Burst int
+	// MaxConnsPerHost caps concurrent requests per host; 0 means unlimited
+	MaxConnsPerHost int
+	// RespectCrawlDelay lowers the rate of hosts whose robots.txt sets a Crawl-delay
+	RespectCrawlDelay bool
+}
+
+type hostLimiter struct {
+	limiter    *rate.Limiter
+	slots      chan struct{}
+	robots     sync.Once
+	crawlDelay time.Duration
+	lastUsed   time.Time
+
+	// waiting is the number of requests blocked on the host right now and
+	// queued the number that ever had to wait
+	waiting   int
+	queued    int64
+	totalWait time.Duration
+	maxWait   time.Duration
+}
+
+// politeness enforces a PolitenessPolicy per host
+type politeness struct {
+	mu        sync.Mutex
+	policy    PolitenessPolicy
+	hosts     map[string]*hostLimiter
+	lastSweep time.Time
+	robots    *http.Client
+	logger    *zap.Logger
+}
+
+// newPoliteness creates the limiter. allow vets every redirect of a robots.txt
+// lookup like the redirects of page fetches, so that the lookup cannot be sent
+// outside the egress allow-list.
+func newPoliteness(policy PolitenessPolicy, transport http.RoundTripper, allow func(rawURL string) error, logger *zap.Logger) *politeness {
+	return &politeness{
+		policy: policy,
+		hosts:  make(map[string]*hostLimiter),
+		robots: &http.Client{
+			Transport: transport,
+			Timeout:   robotsTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > robotsMaxRedirects {
+					return domain.ErrTooManyRedirects
+				}
+				return allow(req.URL.String())
+			},
+		},
+		logger: logger,
+	}
+}
+
+func (p *politeness) enabled() bool {
+	return p != nil && (p.policy.RequestsPerSecond > 0 || p.policy.MaxConnsPerHost > 0)
+}
+
+// acquire blocks until a request to u may be sent and returns a function that
+// must be called once the response has been consumed
+func (p *politeness) acquire(ctx context.Context, u *url.URL) (func(), error) {
+	if !p.enabled() {
+		return func() {}, nil
+	}
+
+	h := p.host(u.Host)
+	if p.policy.RespectCrawlDelay {
+		h.robots.Do(func() { p.applyCrawlDelay(ctx, u, h) })
+	}
+
+	p.setWaiting(h, 1)
+	defer p.setWaiting(h, -1)
+
+	start := time.Now()
+	if h.slots != nil {
+		select {
+		case h.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, classifyError(ctx.Err())
+		}
+	}
+	release := func() {
+		if h.slots != nil {
+			<-h.slots
+		}
+	}
+
+	if h.limiter != nil {
+		if err := h.limiter.Wait(ctx); err != nil {
+			release()
+			// Wait also gives up early when the deadline would pass first
+			cause := ctx.Err()
+			if cause == nil {
+				cause = context.DeadlineExceeded
+			}
+			return nil, classifyError(cause)
+		}
+	}
+
+	if wait := time.Since(start); wait > time.Millisecond {
+		p.recordWait(h, wait)
+		logging.FromContext(ctx, p.logger).Debug("request queued by host limiter",
+			zap.String("host", u.Host),
+			zap.Duration("wait", wait))
+	}
+
+	var once sync.Once
+	return func() { once.Do(release) }, nil
+}
+
+// host returns the limiter for host, creating it on first use
+func (p *politeness) host(host string) *hostLimiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	p.sweep(now)
+
+	h, ok := p.hosts[host]
+	if !ok {
+		h = &hostLimiter{}
+		if p.policy.RequestsPerSecond > 0 {
+			burst := p.policy.Burst
+			if burst < 1 {
+				burst = 1
+			}
+			h.limiter = rate.NewLimiter(rate.Limit(p.policy.RequestsPerSecond), burst)
+		}
+		if p.policy.MaxConnsPerHost > 0 {
+			h.slots = make(chan struct{}, p.policy.MaxConnsPerHost)
+		}
+		p.hosts[host] = h
+	}
+	h.lastUsed = now
+	return h
+}
+
+// sweep forgets hosts that have been idle for a while so the map does not
+// grow without bound. Callers must hold p.mu.
+func (p *politeness) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < time.Minute {
+		return
+	}
+	p.lastSweep = now
+	for host, h := range p.hosts {
+		if now.Sub(h.lastUsed) > hostIdleTTL && len(h.slots) == 0 && h.waiting == 0 {
+			delete(p.hosts, host)
+		}
+	}
+}
+
+func (p *politeness) setWaiting(h *hostLimiter, delta int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h.waiting += delta
+}
+
+func (p *politeness) recordWait(h *hostLimiter, wait time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h.queued++
+	h.totalWait += wait
+	if wait > h.maxWait {
+		h.maxWait = wait
+	}
+}
+
+// applyCrawlDelay fetches robots.txt for the host of u and, when it declares a
+// Crawl-delay for us, slows the host's limiter down to match
+func (p *politeness) applyCrawlDelay(ctx context.Context, u *url.URL, h *hostLimiter) {
+	// The result is shared by every later request to the host, so the lookup
+	// must not fail just because the request that triggered it was cancelled
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), robotsTimeout)
+	defer cancel()
+
+	robotsURL := url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/robots.txt"}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := p.robots.Do(req)
+	if err != nil {
+		p.logger.Debug("robots.txt lookup failed", zap.String("host", u.Host), zap.Error(err))
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	delay := parseCrawlDelay(io.LimitReader(resp.Body, 512<<10), robotsAgent)
+	if delay <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	h.crawlDelay = delay
+	limit := rate.Every(delay)
+	if h.limiter == nil {
+		h.limiter = rate.NewLimiter(limit, 1)
+	} else if limit < h.limiter.Limit() {
+		h.limiter.SetLimit(limit)
+		h.limiter.SetBurst(1)
+	}
+	p.logger.Info("honouring robots.txt crawl delay",
+		zap.String("host", u.Host),
+		zap.Duration("crawl_delay", delay))
+}
+
+// stats returns the limiter state of every host contacted recently
+func (p *politeness) stats() []domain.HostLimitStats {
+	if !p.enabled() {
+		return []domain.HostLimitStats{}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]domain.HostLimitStats, 0, len(p.hosts))
+	for host, h := range p.hosts {
+		s := domain.HostLimitStats{
+			Host:           host,
+			InFlight:       len(h.slots),
+			Waiting:        h.waiting,
+			QueuedRequests: h.queued,
+			TotalWaitMs:    float64(h.totalWait.Microseconds()) / 1000,
+			MaxWaitMs:      float64(h.maxWait.Microseconds()) / 1000,
+			CrawlDelayMs:   h.crawlDelay.Milliseconds(),
+		}
+		if h.limiter != nil {
+			s.RequestsPerSecond = float64(h.limiter.Limit())
+		}
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Host < stats[j].Host })
+	return stats
+}
+
+// parseCrawlDelay returns the Crawl-delay that applies to agent, preferring a
+// group naming the agent over the wildcard group
+func parseCrawlDelay(r io.Reader, agent string) time.Duration {
+	var (
+		agents      []string
+		inRules     bool
+		specific    time.Duration
+		wildcard    time.Duration
+		hasSpecific bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// A user-agent line after rules starts a new group
+			if inRules {
+				agents, inRules = nil, false
+			}
+			agents = append(agents, strings.ToLower(value))
+		case "crawl-delay":
+			inRules = true
+			seconds, err := strconv.ParseFloat(value, 64)
+			if err != nil || seconds <= 0 {
+				continue
+			}
+			delay := time.Duration(seconds * float64(time.Second))
+			for _, a := range agents {
+				switch {
+				case a == "":
+					// An empty user-agent names no crawler
+				case a == "*":
+					wildcard = delay
+				case strings.Contains(agent, a) || strings.Contains(a, agent):
+					specific, hasSpecific = delay, true
+				}
+			}
+		default:
+			inRules = true
+		}
+	}
+
+	if hasSpecific {
+		return specific
+	}
+	return wildcard
+}
+
+// releasingBody releases a host slot once the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
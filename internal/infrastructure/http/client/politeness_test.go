@@ -0,0 +1,280 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+func TestHTTPClient_PolitenessMaxConnsPerHost(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(), WithPoliteness(PolitenessPolicy{MaxConnsPerHost: 2}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+
+	stats := client.HostLimits()
+	if assert.Len(t, stats, 1) {
+		assert.Equal(t, 0, stats[0].InFlight)
+		assert.Equal(t, 0, stats[0].Waiting)
+		assert.Positive(t, stats[0].QueuedRequests)
+		assert.Positive(t, stats[0].MaxWaitMs)
+	}
+}
+
+func TestHTTPClient_PolitenessRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(),
+		WithPoliteness(PolitenessPolicy{RequestsPerSecond: 20, Burst: 1}))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+		assert.NoError(t, err)
+	}
+
+	// The first request uses the burst, the next two wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	stats := client.HostLimits()
+	if assert.Len(t, stats, 1) {
+		assert.Equal(t, float64(20), stats[0].RequestsPerSecond)
+		assert.Equal(t, int64(2), stats[0].QueuedRequests)
+	}
+}
+
+func TestHTTPClient_PolitenessWaitTimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(),
+		WithPoliteness(PolitenessPolicy{RequestsPerSecond: 0.1, Burst: 1}))
+
+	_, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+	assert.NoError(t, err)
+
+	// The next token is ten seconds away, well past the request timeout
+	_, err = client.FetchPage(context.Background(), server.URL, domain.FetchOptions{TimeoutMs: 50})
+	assert.ErrorIs(t, err, domain.ErrTimeout)
+}
+
+func TestHTTPClient_PolitenessWaitCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(),
+		WithPoliteness(PolitenessPolicy{RequestsPerSecond: 0.5, Burst: 1}))
+
+	_, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+	assert.NoError(t, err)
+
+	// The next token is two seconds away, within the request timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err = client.FetchPage(ctx, server.URL, domain.FetchOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, domain.ErrTimeout)
+}
+
+func TestHTTPClient_PolitenessCrawlDelay(t *testing.T) {
+	var robotsRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			atomic.AddInt32(&robotsRequests, 1)
+			w.Write([]byte("User-agent: *\nCrawl-delay: 0.1\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(),
+		WithPoliteness(PolitenessPolicy{RequestsPerSecond: 100, Burst: 10, RespectCrawlDelay: true}))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+		assert.NoError(t, err)
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&robotsRequests))
+	stats := client.HostLimits()
+	if assert.Len(t, stats, 1) {
+		assert.Equal(t, int64(100), stats[0].CrawlDelayMs)
+		assert.InDelta(t, 10, stats[0].RequestsPerSecond, 0.001)
+	}
+}
+
+func TestHTTPClient_PolitenessCrawlDelayOutlivesCaller(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.Write([]byte("User-agent: *\nCrawl-delay: 0.1\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient(5*time.Second, zap.NewNop(),
+		WithPoliteness(PolitenessPolicy{RequestsPerSecond: 100, Burst: 10, RespectCrawlDelay: true}))
+
+	// The first caller gives up, but robots.txt is still read for the others
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.FetchPage(ctx, server.URL, domain.FetchOptions{})
+	assert.Error(t, err)
+
+	stats := client.HostLimits()
+	if assert.Len(t, stats, 1) {
+		assert.Equal(t, int64(100), stats[0].CrawlDelayMs)
+	}
+}
+
+func TestHTTPClient_PolitenessRobotsRedirectEgress(t *testing.T) {
+	var outsideRequests int32
+	outside := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&outsideRequests, 1)
+		w.Write([]byte("User-agent: *\nCrawl-delay: 5\n"))
+	}))
+	defer outside.Close()
+	outsideURL, _ := url.Parse(outside.URL)
+
+	newServer := func(location string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/robots.txt":
+				http.Redirect(w, r, location, http.StatusMovedPermanently)
+			case "/moved-robots.txt":
+				w.Write([]byte("User-agent: *\nCrawl-delay: 0.1\n"))
+			default:
+				w.Header().Set("Content-Type", "text/html")
+				w.Write([]byte("<html></html>"))
+			}
+		}))
+	}
+	// Only 127.0.0.1 is allowed, so the redirect to localhost leaves the allow-list
+	leaving := newServer("http://localhost:" + outsideURL.Port() + "/robots.txt")
+	defer leaving.Close()
+	staying := newServer("/moved-robots.txt")
+	defer staying.Close()
+
+	for _, server := range []*httptest.Server{leaving, staying} {
+		client := NewHTTPClient(5*time.Second, zap.NewNop(), WithAllowedHosts([]string{"127.0.0.1"}),
+			WithPoliteness(PolitenessPolicy{RequestsPerSecond: 100, Burst: 10, RespectCrawlDelay: true}))
+		_, err := client.FetchPage(context.Background(), server.URL, domain.FetchOptions{})
+		assert.NoError(t, err)
+
+		stats := client.HostLimits()
+		if assert.Len(t, stats, 1) && server == staying {
+			assert.Equal(t, int64(100), stats[0].CrawlDelayMs)
+		}
+	}
+	assert.Zero(t, atomic.LoadInt32(&outsideRequests))
+}
+
+func TestHTTPClient_PolitenessDisabled(t *testing.T) {
+	client := NewHTTPClient(5*time.Second, zap.NewNop())
+	assert.Empty(t, client.HostLimits())
+}
+
+func TestParseCrawlDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		robots   string
+		expected time.Duration
+	}{
+		{
+			name:     "Wildcard group",
+			robots:   "User-agent: *\nDisallow: /private\nCrawl-delay: 2",
+			expected: 2 * time.Second,
+		},
+		{
+			name:     "Specific group wins over wildcard",
+			robots:   "User-agent: *\nCrawl-delay: 10\n\nUser-agent: WebAnalyzer\nCrawl-delay: 1.5",
+			expected: 1500 * time.Millisecond,
+		},
+		{
+			name:     "Other agents are ignored",
+			robots:   "User-agent: Googlebot\nCrawl-delay: 5",
+			expected: 0,
+		},
+		{
+			name:     "Grouped user agents share rules",
+			robots:   "User-agent: Bingbot\nUser-agent: webanalyzer\nCrawl-delay: 3 # be gentle",
+			expected: 3 * time.Second,
+		},
+		{
+			name:     "Empty user agent names no one",
+			robots:   "User-agent:\nCrawl-delay: 7\n\nUser-agent: *\nCrawl-delay: 1",
+			expected: time.Second,
+		},
+		{
+			name:     "Invalid delay",
+			robots:   "User-agent: *\nCrawl-delay: soon",
+			expected: 0,
+		},
+		{
+			name:     "No crawl delay",
+			robots:   "User-agent: *\nDisallow:",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseCrawlDelay(strings.NewReader(tt.robots), robotsAgent))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxyOverrideKey carries the per-request proxy from FetchOptions to the transport
+type proxyOverrideKey struct{}
+
+// proxyError reports that a proxy refused to open a tunnel to the target
+type proxyError struct {
+	statusCode int
+	status     string
+}
+
+func (e *proxyError) Error() string {
+	return "proxy refused CONNECT: " + e.status
+}
+
+// proxySelector decides which proxy, if any, a request is sent through
+type proxySelector struct {
+	noProxy  string
+	fallback func(*url.URL) (*url.URL, error)
+	// allowed holds the proxies requests may name, keyed by proxyKey
+	allowed map[string]bool
+}
+
+func newProxySelector(proxyURL *url.URL, noProxy string, allowed []string) *proxySelector {
+	s := &proxySelector{noProxy: noProxy, allowed: make(map[string]bool)}
+	if proxyURL != nil {
+		s.fallback = s.rules(proxyURL.String())
+	}
+	for _, proxy := range allowed {
+		if key := proxyKey(proxy); key != "" {
+			s.allowed[key] = true
+		}
+	}
+	return s
+}
+
+// allow rejects a per-request proxy that is not on the allow-list, and
+// bypassing the configured proxy, so that callers cannot route around the
+// egress controls of the operator
+func (s *proxySelector) allow(override string) error {
+	switch {
+	case override == "":
+		return nil
+	case override == domain.ProxyDirect:
+		if s.fallback == nil {
+			return nil
+		}
+		return proxyNotAllowed("must not bypass the configured proxy")
+	case s.allowed[proxyKey(override)]:
+		return nil
+	default:
+		return proxyNotAllowed("is not an allowed proxy")
+	}
+}
+
+func proxyNotAllowed(message string) error {
+	return domain.ErrValidationFailed.WithFields([]domain.FieldError{
+		{Field: "proxy", Rule: "allowed", Message: message},
+	})
+}
+
+// proxyKey identifies a proxy by scheme and address, so that callers may send
+// their own credentials to an allowed proxy
+func proxyKey(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// rules returns a proxy func that sends requests through proxyURL unless the
+// target matches the NO_PROXY rules
+func (s *proxySelector) rules(proxyURL string) func(*url.URL) (*url.URL, error) {
+	return (&httpproxy.Config{
+		HTTPProxy:  proxyURL,
+		HTTPSProxy: proxyURL,
+		NoProxy:    s.noProxy,
+	}).ProxyFunc()
+}
+
+// proxy is used as http.Transport.Proxy
+func (s *proxySelector) proxy(req *http.Request) (*url.URL, error) {
+	if override, ok := req.Context().Value(proxyOverrideKey{}).(string); ok {
+		if override == domain.ProxyDirect {
+			return nil, nil
+		}
+		return s.rules(override)(req.URL)
+	}
+	if s.fallback == nil {
+		return nil, nil
+	}
+	return s.fallback(req.URL)
+}
+
+// withProxyOverride stores the per-request proxy in ctx
+func withProxyOverride(ctx context.Context, proxy string) context.Context {
+	if proxy == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, proxyOverrideKey{}, proxy)
+}
+
+// checkProxyConnect turns a failed CONNECT into a proxyError so it can be told
+// apart from failures of the target itself
+func checkProxyConnect(_ context.Context, _ *url.URL, _ *http.Request, resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return &proxyError{statusCode: resp.StatusCode, status: resp.Status}
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy configures how failed requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles on every attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A Retry-After longer than this is not waited for.
+	MaxDelay time.Duration
+	// Timeout bounds all attempts of a fetch and the waits between them
+	// together; a request's own timeout takes its place. Zero means no bound.
+	Timeout time.Duration
+}
+
+// retryingClient decorates a ports.HTTPClient with retries and a per-host
+// circuit breaker
+type retryingClient struct {
+	next     ports.HTTPClient
+	policy   atomic.Pointer[RetryPolicy]
+	breakers *breakerRegistry
+	logger   *zap.Logger
+}
+
+func NewRetryingClient(next ports.HTTPClient, policy RetryPolicy, breaker BreakerPolicy, logger *zap.Logger) *retryingClient {
+	c := &retryingClient{
+		next:     next,
+		breakers: newBreakerRegistry(breaker),
+		logger:   logger,
+	}
+	c.SetRetryPolicy(policy)
+	return c
+}
+
+// SetRetryPolicy replaces the retry policy. Requests already retrying finish
+// under the policy they started with.
+func (c *retryingClient) SetRetryPolicy(policy RetryPolicy) {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	c.policy.Store(&policy)
+}
+
+func (c *retryingClient) FetchPage(ctx context.Context, rawURL string, opts domain.FetchOptions) (*domain.FetchResult, error) {
+	host := hostOf(rawURL)
+	policy := c.policy.Load()
+
+	timeout := policy.Timeout
+	if opts.TimeoutMs > 0 {
+		timeout = time.Duration(opts.TimeoutMs) * time.Millisecond
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if ok, wait := c.breakers.allow(host); !ok {
+			c.log(ctx).Warn("circuit open, skipping request",
+				zap.String("host", host),
+				zap.Duration("retry_in", wait))
+			// The circuit may have opened because of our own earlier attempts,
+			// in which case their error is more useful to the caller
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			circuitErr := *domain.ErrCircuitOpen
+			circuitErr.RetryAfter = wait
+			return nil, &circuitErr
+		}
+
+		result, err := c.next.FetchPage(ctx, rawURL, opts)
+		if err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled) ||
+			errors.Is(err, domain.ErrValidationFailed) || errors.Is(err, domain.ErrDestinationNotAllowed)) {
+			// The caller went away, the fetch ran out of the time it was given
+			// or the request was rejected before it was sent, none of which
+			// says anything about the host
+			c.breakers.release(host)
+			return nil, err
+		}
+		lastErr = err
+		c.breakers.record(host, hostFailure(err))
+		if err == nil {
+			return result, nil
+		}
+
+		if attempt >= policy.MaxAttempts || !retryable(http.MethodGet, err) {
+			return nil, err
+		}
+
+		delay, ok := policy.delay(attempt, err)
+		if !ok {
+			return nil, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			c.log(ctx).Info("not retrying, the request would time out during the backoff",
+				zap.String("url", rawURL),
+				zap.Duration("delay", delay),
+				zap.Error(err))
+			return nil, err
+		}
+
+		c.log(ctx).Info("retrying request",
+			zap.String("url", rawURL),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// CheckLink is not retried because link checks are best-effort probes, but
+// requests to hosts with an open circuit are skipped. Their outcome counts
+// towards the circuit like that of page fetches.
+func (c *retryingClient) CheckLink(ctx context.Context, rawURL string) domain.LinkCheckResult {
+	host := hostOf(rawURL)
+	if ok, _ := c.breakers.allow(host); !ok {
+		return domain.LinkCheckResult{
+			URL:    rawURL,
+			Status: domain.LinkBroken,
+			Error:  domain.ErrCircuitOpen.Code,
+		}
+	}
+
+	result := c.next.CheckLink(ctx, rawURL)
+	if ctx.Err() != nil {
+		// The check was abandoned, which says nothing about the host
+		c.breakers.release(host)
+		return result
+	}
+	c.breakers.record(host, linkFailure(result))
+	return result
+}
+
+// CircuitBreakers returns the state of every host with recent failures
+func (c *retryingClient) CircuitBreakers() []domain.CircuitBreakerState {
+	return c.breakers.snapshot()
+}
+
+// delay returns how long to wait before the next attempt using exponential
+// backoff with full jitter. A Retry-After sent by the target takes precedence;
+// ok is false when it asks for a longer wait than the policy allows.
+func (p *RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if p.MaxDelay > 0 && apiErr.RetryAfter > p.MaxDelay {
+			return 0, false
+		}
+		return apiErr.RetryAfter, true
+	}
+
+	backoff := p.BaseDelay << (attempt - 1)
+	if p.MaxDelay > 0 && (backoff > p.MaxDelay || backoff <= 0) {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1)), true
+}
+
+// retryable reports whether a failed request may be sent again. Requests with
+// idempotent methods are retried on transient failures; anything else only
+// when the request provably never reached the server.
+func retryable(method string, err error) bool {
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	switch apiErr.Code {
+	case domain.CodeConnectionRefused:
+		return true
+	case domain.CodeConnectionReset, domain.CodeTimeout, domain.CodePageNotAccessible:
+		return isIdempotent(method)
+	case domain.CodeUpstreamServerError:
+		switch apiErr.UpstreamStatus {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return isIdempotent(method)
+		}
+	case domain.CodeUpstreamClientError:
+		switch apiErr.UpstreamStatus {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests:
+			return isIdempotent(method)
+		}
+	}
+	return false
+}
+
+// hostFailure returns err when it means the host itself is unhealthy and nil
+// when the host answered, even if the answer was an error page, or when the
+// request was cancelled by the caller
+func hostFailure(err error) error {
+	if err != nil && retryable(http.MethodGet, err) {
+		return err
+	}
+	return nil
+}
+
+// linkFailure is hostFailure for a link check, which reports an error code or
+// status code instead of an error
+func linkFailure(result domain.LinkCheckResult) error {
+	if result.Error != "" {
+		return hostFailure(&domain.APIError{Code: result.Error, Message: result.Error})
+	}
+	if result.StatusCode >= http.StatusInternalServerError {
+		return hostFailure(domain.NewUpstreamError(result.StatusCode,
+			strconv.Itoa(result.StatusCode)+" "+http.StatusText(result.StatusCode)))
+	}
+	return nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Host
+}
+
+// log returns the request-scoped logger carried by ctx
+func (c *retryingClient) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, c.logger)
+}
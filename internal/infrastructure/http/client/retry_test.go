@@ -0,0 +1,349 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+type mockHTTPClient struct {
+	mock.Mock
+}
+
+func (m *mockHTTPClient) FetchPage(ctx context.Context, url string, opts domain.FetchOptions) (*domain.FetchResult, error) {
+	args := m.Called(ctx, url, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FetchResult), args.Error(1)
+}
+
+func (m *mockHTTPClient) CheckLink(ctx context.Context, url string) domain.LinkCheckResult {
+	args := m.Called(ctx, url)
+	return args.Get(0).(domain.LinkCheckResult)
+}
+
+func TestRetryingClient_FetchPage(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	page := &domain.FetchResult{Body: "<html></html>"}
+	unavailable := domain.NewUpstreamError(http.StatusServiceUnavailable, "503 Service Unavailable")
+
+	tests := []struct {
+		name          string
+		setupMock     func(*mockHTTPClient)
+		expectedError error
+		expectedCalls int
+	}{
+		{
+			name: "Succeeds after transient failures",
+			setupMock: func(m *mockHTTPClient) {
+				m.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, domain.ErrConnectionReset).Once()
+				m.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, unavailable).Once()
+				m.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(page, nil).Once()
+			},
+			expectedCalls: 3,
+		},
+		{
+			name: "Gives up after max attempts",
+			setupMock: func(m *mockHTTPClient) {
+				m.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, domain.ErrTimeout)
+			},
+			expectedError: domain.ErrTimeout,
+			expectedCalls: 3,
+		},
+		{
+			name: "Permanent errors are not retried",
+			setupMock: func(m *mockHTTPClient) {
+				m.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, domain.ErrPageNotFound)
+			},
+			expectedError: domain.ErrPageNotFound,
+			expectedCalls: 1,
+		},
+		{
+			name: "DNS failures are not retried",
+			setupMock: func(m *mockHTTPClient) {
+				m.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, domain.ErrDNSResolutionFailed)
+			},
+			expectedError: domain.ErrDNSResolutionFailed,
+			expectedCalls: 1,
+		},
+		{
+			name: "Too many requests is retried",
+			setupMock: func(m *mockHTTPClient) {
+				m.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(nil, domain.NewUpstreamError(http.StatusTooManyRequests, "429 Too Many Requests")).Once()
+				m.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+					Return(page, nil).Once()
+			},
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := new(mockHTTPClient)
+			tt.setupMock(next)
+
+			client := NewRetryingClient(next,
+				RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond},
+				BreakerPolicy{},
+				logger)
+
+			result, err := client.FetchPage(context.Background(), "https://example.com", domain.FetchOptions{})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, page, result)
+			}
+			next.AssertNumberOfCalls(t, "FetchPage", tt.expectedCalls)
+		})
+	}
+}
+
+func TestRetryingClient_RetryAfter(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	throttled := domain.NewUpstreamError(http.StatusServiceUnavailable, "503 Service Unavailable")
+	throttled.RetryAfter = 50 * time.Millisecond
+
+	next := new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).Return(nil, throttled).Once()
+	next.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).Return(&domain.FetchResult{}, nil).Once()
+
+	client := NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+		BreakerPolicy{},
+		logger)
+
+	start := time.Now()
+	_, err := client.FetchPage(context.Background(), "https://example.com", domain.FetchOptions{})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// A Retry-After beyond the maximum delay is not waited for
+	throttled.RetryAfter = time.Hour
+	next = new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).Return(nil, throttled)
+	client = NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+		BreakerPolicy{},
+		logger)
+
+	_, err = client.FetchPage(context.Background(), "https://example.com", domain.FetchOptions{})
+	assert.ErrorIs(t, err, domain.ErrUpstreamServerError)
+	next.AssertNumberOfCalls(t, "FetchPage", 1)
+}
+
+func TestRetryingClient_SetRetryPolicy(t *testing.T) {
+	next := new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).Return(nil, domain.ErrTimeout)
+
+	client := NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 1},
+		BreakerPolicy{},
+		zap.NewNop())
+
+	_, _ = client.FetchPage(context.Background(), "https://example.com", domain.FetchOptions{})
+	next.AssertNumberOfCalls(t, "FetchPage", 1)
+
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	_, _ = client.FetchPage(context.Background(), "https://example.com", domain.FetchOptions{})
+	next.AssertNumberOfCalls(t, "FetchPage", 4)
+}
+
+func TestRetryingClient_CircuitBreaker(t *testing.T) {
+	// Initialize logger
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	next := new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://down.example.com", mock.Anything).
+		Return(nil, domain.ErrConnectionRefused).Times(2)
+	next.On("FetchPage", mock.Anything, "https://down.example.com", mock.Anything).
+		Return(&domain.FetchResult{}, nil).Once()
+	next.On("FetchPage", mock.Anything, "https://up.example.com", mock.Anything).
+		Return(&domain.FetchResult{}, nil)
+
+	client := NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 1},
+		BreakerPolicy{FailureThreshold: 2, Cooldown: 50 * time.Millisecond},
+		logger)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.FetchPage(ctx, "https://down.example.com", domain.FetchOptions{})
+		assert.ErrorIs(t, err, domain.ErrConnectionRefused)
+	}
+
+	// The circuit is open, so the host is not contacted
+	_, err := client.FetchPage(ctx, "https://down.example.com", domain.FetchOptions{})
+	assert.ErrorIs(t, err, domain.ErrCircuitOpen)
+	link := client.CheckLink(ctx, "https://down.example.com/page")
+	assert.False(t, link.Reachable())
+	assert.Equal(t, domain.ErrCircuitOpen.Code, link.Error)
+	next.AssertNumberOfCalls(t, "FetchPage", 2)
+
+	// Other hosts are unaffected
+	_, err = client.FetchPage(ctx, "https://up.example.com", domain.FetchOptions{})
+	assert.NoError(t, err)
+
+	states := client.CircuitBreakers()
+	assert.Len(t, states, 1)
+	assert.Equal(t, "down.example.com", states[0].Host)
+	assert.Equal(t, breakerOpen, states[0].State)
+	assert.Equal(t, 2, states[0].ConsecutiveFailures)
+	assert.NotNil(t, states[0].OpenedAt)
+
+	// After the cooldown a trial request is let through and closes the circuit
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.FetchPage(ctx, "https://down.example.com", domain.FetchOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, client.CircuitBreakers())
+}
+
+func TestRetryingClient_CheckLinkCircuitBreaker(t *testing.T) {
+	next := new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://down.example.com", mock.Anything).
+		Return(nil, domain.ErrConnectionRefused)
+	next.On("CheckLink", mock.Anything, "https://down.example.com/refused").
+		Return(domain.LinkCheckResult{Status: domain.LinkBroken, Error: domain.CodeConnectionRefused})
+	next.On("CheckLink", mock.Anything, "https://down.example.com/ok").
+		Return(domain.LinkCheckResult{Status: domain.LinkReachable, StatusCode: http.StatusOK})
+
+	cooldown := 20 * time.Millisecond
+	client := NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 1},
+		BreakerPolicy{FailureThreshold: 1, Cooldown: cooldown},
+		zap.NewNop())
+	ctx := context.Background()
+
+	_, err := client.FetchPage(ctx, "https://down.example.com", domain.FetchOptions{})
+	assert.ErrorIs(t, err, domain.ErrConnectionRefused)
+
+	// A failed trial link check opens the circuit again
+	time.Sleep(cooldown + 5*time.Millisecond)
+	assert.Equal(t, domain.CodeConnectionRefused, client.CheckLink(ctx, "https://down.example.com/refused").Error)
+	assert.Equal(t, breakerOpen, client.CircuitBreakers()[0].State)
+
+	// An abandoned trial leaves the next request to find out
+	time.Sleep(cooldown + 5*time.Millisecond)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	client.CheckLink(cancelled, "https://down.example.com/ok")
+	assert.Equal(t, breakerOpen, client.CircuitBreakers()[0].State)
+
+	// A successful trial link check closes it
+	assert.True(t, client.CheckLink(ctx, "https://down.example.com/ok").Reachable())
+	assert.Empty(t, client.CircuitBreakers())
+}
+
+func TestRetryingClient_Cancelled(t *testing.T) {
+	// A cancelled request surfaces as the error the transport reports for it
+	cancelled := domain.ErrPageNotAccessible.WithCause(context.Canceled)
+
+	next := new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).Return(nil, cancelled)
+
+	client := NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+		BreakerPolicy{FailureThreshold: 1, Cooldown: time.Minute},
+		zap.NewNop())
+
+	for i := 0; i < 3; i++ {
+		_, err := client.FetchPage(context.Background(), "https://example.com", domain.FetchOptions{})
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+	next.AssertNumberOfCalls(t, "FetchPage", 3)
+	assert.Empty(t, client.CircuitBreakers())
+}
+
+func TestRetryingClient_Timeout(t *testing.T) {
+	var deadlines []time.Time
+	next := new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+		Run(func(args mock.Arguments) {
+			deadline, _ := args.Get(0).(context.Context).Deadline()
+			deadlines = append(deadlines, deadline)
+		}).
+		Return(nil, domain.ErrConnectionReset)
+
+	client := NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 5, BaseDelay: 20 * time.Millisecond, MaxDelay: 20 * time.Millisecond, Timeout: 100 * time.Millisecond},
+		BreakerPolicy{},
+		zap.NewNop())
+
+	start := time.Now()
+	_, err := client.FetchPage(context.Background(), "https://example.com", domain.FetchOptions{})
+	assert.ErrorIs(t, err, domain.ErrConnectionReset)
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+
+	// Every attempt shares the one deadline
+	assert.NotEmpty(t, deadlines)
+	for _, d := range deadlines {
+		assert.Equal(t, deadlines[0], d)
+	}
+
+	// No retry is attempted when the backoff would outlast the deadline
+	unavailable := domain.NewUpstreamError(http.StatusServiceUnavailable, "503 Service Unavailable").WithRetryAfter(time.Second)
+	next = new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).Return(nil, unavailable)
+	client = NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Minute, Timeout: time.Minute},
+		BreakerPolicy{},
+		zap.NewNop())
+
+	_, err = client.FetchPage(context.Background(), "https://example.com", domain.FetchOptions{TimeoutMs: 50})
+	assert.ErrorIs(t, err, unavailable)
+	next.AssertNumberOfCalls(t, "FetchPage", 1)
+}
+
+func TestRetryingClient_CallerDeadline(t *testing.T) {
+	next := new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://slow.example.com", mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(nil, domain.ErrTimeout)
+
+	client := NewRetryingClient(next,
+		RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+		BreakerPolicy{FailureThreshold: 1, Cooldown: time.Minute},
+		zap.NewNop())
+
+	// A short timeout chosen by the caller is neither retried nor held against
+	// the host
+	_, err := client.FetchPage(context.Background(), "https://slow.example.com", domain.FetchOptions{TimeoutMs: 20})
+	assert.ErrorIs(t, err, domain.ErrTimeout)
+	next.AssertNumberOfCalls(t, "FetchPage", 1)
+	assert.Empty(t, client.CircuitBreakers())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 120*time.Second, parseRetryAfter("120"))
+	assert.Zero(t, parseRetryAfter(""))
+	assert.Zero(t, parseRetryAfter("soon"))
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	wait := parseRetryAfter(date)
+	assert.Greater(t, wait, 58*time.Second)
+	assert.LessOrEqual(t, wait, time.Minute)
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+// fetchTrace collects connection timings for a single HTTP request. Hooks may
+// run concurrently, for parallel dials to several addresses, and even after
+// the round trip for dials that lost the race, so every field is guarded by mu.
+type fetchTrace struct {
+	start time.Time
+
+	mu           sync.Mutex
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+	reused       bool
+}
+
+func newFetchTrace() *fetchTrace {
+	return &fetchTrace{start: time.Now()}
+}
+
+func (t *fetchTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.set(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.set(&t.dnsDone) },
+		// Only the first dial attempt and the first successful one are timed
+		// when several addresses are tried
+		ConnectStart: func(network, addr string) { t.set(&t.connectStart) },
+		ConnectDone: func(network, addr string, err error) {
+			if err == nil {
+				t.set(&t.connectDone)
+			}
+		},
+		TLSHandshakeStart: func() { t.set(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.set(&t.tlsDone) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.reused = info.Reused
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() { t.set(&t.firstByte) },
+	}
+}
+
+// set records the current time in field unless an earlier hook already did
+func (t *fetchTrace) set(field *time.Time) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if field.IsZero() {
+		*field = now
+	}
+}
+
+// metrics converts the collected timings into the domain representation.
+// downloadDone marks the moment the response body was fully read.
+func (t *fetchTrace) metrics(downloadDone time.Time) domain.PerformanceMetrics {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m := domain.PerformanceMetrics{
+		DNSLookupMs:      milliseconds(t.dnsStart, t.dnsDone),
+		TCPConnectMs:     milliseconds(t.connectStart, t.connectDone),
+		TLSHandshakeMs:   milliseconds(t.tlsStart, t.tlsDone),
+		ConnectionReused: t.reused,
+	}
+	if !t.firstByte.IsZero() {
+		m.TimeToFirstByteMs = milliseconds(t.start, t.firstByte)
+		m.ContentDownloadMs = milliseconds(t.firstByte, downloadDone)
+	}
+	return m
+}
+
+func milliseconds(from, to time.Time) float64 {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return 0
+	}
+	return float64(to.Sub(from).Microseconds()) / 1000
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+)
+
+// Analysis phases recorded in analysis_phase_duration_seconds
+const (
+	PhaseFetch       = "fetch"
+	PhaseHTMLVersion = "html_version"
+	PhaseTitle       = "title"
+	PhaseHeadings    = "headings"
+	PhaseLinks       = "links"
+	PhaseLoginForm   = "login_form"
+)
+
+// instrumentedAnalyzer records the duration and outcome of whole analyses
+type instrumentedAnalyzer struct {
+	next    ports.PageAnalyzer
+	metrics *Metrics
+}
+
+func InstrumentAnalyzer(next ports.PageAnalyzer, m *Metrics) ports.PageAnalyzer {
+	return &instrumentedAnalyzer{next: next, metrics: m}
+}
+
+func (a *instrumentedAnalyzer) Analyze(ctx context.Context, req domain.AnalysisRequest) (*domain.PageAnalysis, error) {
+	start := time.Now()
+	analysis, err := a.next.Analyze(ctx, req)
+	a.observe("url", start, err)
+	return analysis, err
+}
+
+func (a *instrumentedAnalyzer) AnalyzeHTML(ctx context.Context, req domain.HTMLAnalysisRequest) (*domain.PageAnalysis, error) {
+	start := time.Now()
+	analysis, err := a.next.AnalyzeHTML(ctx, req)
+	a.observe("html", start, err)
+	return analysis, err
+}
+
+func (a *instrumentedAnalyzer) observe(source string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = errorType(err)
+	}
+	a.metrics.analyses.WithLabelValues(source, outcome).Inc()
+	a.metrics.analysisDuration.WithLabelValues(source).Observe(time.Since(start).Seconds())
+}
+
+// instrumentedParser times each parser check as its own phase
+type instrumentedParser struct {
+	next    ports.HTMLParser
+	metrics *Metrics
+}
+
+func InstrumentParser(next ports.HTMLParser, m *Metrics) ports.HTMLParser {
+	return &instrumentedParser{next: next, metrics: m}
+}
+
+func (p *instrumentedParser) GetHTMLVersion(ctx context.Context, doc string) string {
+	defer p.metrics.observePhase(PhaseHTMLVersion, time.Now())
+	return p.next.GetHTMLVersion(ctx, doc)
+}
+
+func (p *instrumentedParser) GetTitle(ctx context.Context, doc string) string {
+	defer p.metrics.observePhase(PhaseTitle, time.Now())
+	return p.next.GetTitle(ctx, doc)
+}
+
+func (p *instrumentedParser) CountHeadings(ctx context.Context, doc string) domain.HeadingCount {
+	defer p.metrics.observePhase(PhaseHeadings, time.Now())
+	return p.next.CountHeadings(ctx, doc)
+}
+
+func (p *instrumentedParser) AnalyzeLinks(ctx context.Context, doc string, baseURL string) domain.LinkAnalysis {
+	defer p.metrics.observePhase(PhaseLinks, time.Now())
+	return p.next.AnalyzeLinks(ctx, doc, baseURL)
+}
+
+// ExtractLinks is not timed as a phase of its own; the link checks it feeds
+// are counted by the instrumented client
+func (p *instrumentedParser) ExtractLinks(ctx context.Context, doc string, baseURL string) []string {
+	return p.next.ExtractLinks(ctx, doc, baseURL)
+}
+
+func (p *instrumentedParser) HasLoginForm(ctx context.Context, doc string) bool {
+	defer p.metrics.observePhase(PhaseLoginForm, time.Now())
+	return p.next.HasLoginForm(ctx, doc)
+}
+
+// instrumentedClient counts outbound fetches, cache use and link checks
+type instrumentedClient struct {
+	next    ports.HTTPClient
+	metrics *Metrics
+}
+
+func InstrumentClient(next ports.HTTPClient, m *Metrics) ports.HTTPClient {
+	return &instrumentedClient{next: next, metrics: m}
+}
+
+func (c *instrumentedClient) FetchPage(ctx context.Context, rawURL string, opts domain.FetchOptions) (*domain.FetchResult, error) {
+	start := time.Now()
+	result, err := c.next.FetchPage(ctx, rawURL, opts)
+	c.metrics.observePhase(PhaseFetch, start)
+
+	errType := "none"
+	if err != nil {
+		errType = errorType(err)
+	}
+	c.metrics.fetches.WithLabelValues(hostClass(rawURL), errType).Inc()
+	if err == nil && result.Cache != nil {
+		c.metrics.cacheLookups.WithLabelValues(result.Cache.Status).Inc()
+	}
+	return result, err
+}
+
+func (c *instrumentedClient) CheckLink(ctx context.Context, rawURL string) domain.LinkCheckResult {
+	c.metrics.linkChecksInFlight.Inc()
+	defer c.metrics.linkChecksInFlight.Dec()
+
+	result := c.next.CheckLink(ctx, rawURL)
+	c.metrics.linkChecks.WithLabelValues(result.Status).Inc()
+	return result
+}
+
+// errorType labels errors by their API error code so that the label set
+// stays bounded
+func errorType(err error) string {
+	var apiErr *domain.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.Code
+	case errors.Is(err, context.Canceled):
+		return "CANCELED"
+	case errors.Is(err, context.DeadlineExceeded):
+		return domain.ErrTimeout.Code
+	default:
+		return "OTHER"
+	}
+}
+
+// hostClass groups hosts into a few classes instead of labelling by host:
+// private for loopback, private and link-local addresses and local names,
+// ip for other address literals and domain for everything else
+func hostClass(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return "invalid"
+	}
+	host := strings.ToLower(u.Hostname())
+
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+			return "private"
+		}
+		return "ip"
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") ||
+		strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".internal") {
+		return "private"
+	}
+	return "domain"
+}
@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+)
+
+const namespace = "webanalyzer"
+
+// Metrics holds the Prometheus collectors of the service in their own registry
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+
+	analyses         *prometheus.CounterVec
+	analysisDuration *prometheus.HistogramVec
+	phaseDuration    *prometheus.HistogramVec
+
+	fetches            *prometheus.CounterVec
+	cacheLookups       *prometheus.CounterVec
+	linkChecks         *prometheus.CounterVec
+	linkChecksInFlight prometheus.Gauge
+
+	reloads        *prometheus.CounterVec
+	lastReloadTime prometheus.Gauge
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Inbound HTTP requests by route, method and status.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Inbound HTTP request latency by route, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		analyses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "analyses_total",
+			Help:      "Analyses by source (url or html) and outcome (ok or error code).",
+		}, []string{"source", "outcome"}),
+		analysisDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "analysis_duration_seconds",
+			Help:      "End-to-end analysis duration by source.",
+			Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"source"}),
+		phaseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "analysis_phase_duration_seconds",
+			Help:      "Duration of each analysis phase: fetch and the individual parser checks.",
+			Buckets:   []float64{.0005, .001, .005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"phase"}),
+		fetches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "outbound_fetches_total",
+			Help:      "Outbound page fetches by host class (domain, ip or private) and error type (none or error code).",
+		}, []string{"host_class", "error"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "fetch_cache_lookups_total",
+			Help:      "Response cache outcome of successful fetches: hit, revalidated, miss or bypass.",
+		}, []string{"status"}),
+		linkChecks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "link_checks_total",
+			Help:      "Link checks by result status.",
+		}, []string{"status"}),
+		linkChecksInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "link_checks_in_flight",
+			Help:      "Link checks currently running.",
+		}),
+		reloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "config_reloads_total",
+			Help:      "Configuration reloads by trigger (sighup or file) and outcome (applied, unchanged or failed).",
+		}, []string{"trigger", "outcome"}),
+		lastReloadTime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "config_last_reload_success_timestamp_seconds",
+			Help:      "Unix time of the last configuration reload that was not rejected.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.analyses,
+		m.analysisDuration,
+		m.phaseDuration,
+		m.fetches,
+		m.cacheLookups,
+		m.linkChecks,
+		m.linkChecksInFlight,
+		m.reloads,
+		m.lastReloadTime,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRequest records a completed inbound request. Unmatched routes should
+// be passed as an empty route so that scanners cannot create new series.
+func (m *Metrics) ObserveRequest(route, method string, status int, d time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+	labels := prometheus.Labels{"route": route, "method": method, "status": statusLabel(status)}
+	m.requests.With(labels).Inc()
+	m.requestDuration.With(labels).Observe(d.Seconds())
+}
+
+// RegisterQueue reports the number of outbound requests waiting on per-host
+// politeness limits, the only queue requests pass through
+func (m *Metrics) RegisterQueue(limits ports.HostLimitReporter) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbound_queue_depth",
+		Help:      "Outbound requests waiting for a per-host politeness slot.",
+	}, func() float64 {
+		var waiting int
+		for _, h := range limits.HostLimits() {
+			waiting += h.Waiting
+		}
+		return float64(waiting)
+	}))
+}
+
+// ObserveReload records the outcome of a configuration reload
+func (m *Metrics) ObserveReload(trigger, outcome string) {
+	m.reloads.WithLabelValues(trigger, outcome).Inc()
+	if outcome != "failed" {
+		m.lastReloadTime.SetToCurrentTime()
+	}
+}
+
+func (m *Metrics) observePhase(phase string, start time.Time) {
+	m.phaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
+
+func statusLabel(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status)
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+type mockHTTPClient struct {
+	mock.Mock
+}
+
+func (m *mockHTTPClient) FetchPage(ctx context.Context, url string, opts domain.FetchOptions) (*domain.FetchResult, error) {
+	args := m.Called(ctx, url, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FetchResult), args.Error(1)
+}
+
+func (m *mockHTTPClient) CheckLink(ctx context.Context, url string) domain.LinkCheckResult {
+	args := m.Called(ctx, url)
+	return args.Get(0).(domain.LinkCheckResult)
+}
+
+type stubHostLimitReporter []domain.HostLimitStats
+
+func (s stubHostLimitReporter) HostLimits() []domain.HostLimitStats {
+	return s
+}
+
+func TestInstrumentClient(t *testing.T) {
+	m := New()
+
+	next := new(mockHTTPClient)
+	next.On("FetchPage", mock.Anything, "https://example.com", mock.Anything).
+		Return(&domain.FetchResult{Cache: &domain.CacheInfo{Status: domain.CacheHit}}, nil)
+	next.On("FetchPage", mock.Anything, "http://127.0.0.1:8080", mock.Anything).
+		Return(nil, domain.ErrConnectionRefused)
+	next.On("FetchPage", mock.Anything, "https://93.184.216.34", mock.Anything).
+		Return(nil, context.DeadlineExceeded)
+	next.On("CheckLink", mock.Anything, "https://example.com/a").
+		Return(domain.LinkCheckResult{Status: domain.LinkBroken})
+
+	client := InstrumentClient(next, m)
+	ctx := context.Background()
+	_, _ = client.FetchPage(ctx, "https://example.com", domain.FetchOptions{})
+	_, _ = client.FetchPage(ctx, "http://127.0.0.1:8080", domain.FetchOptions{})
+	_, _ = client.FetchPage(ctx, "https://93.184.216.34", domain.FetchOptions{})
+	client.CheckLink(ctx, "https://example.com/a")
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.fetches.WithLabelValues("domain", "none")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.fetches.WithLabelValues("private", domain.ErrConnectionRefused.Code)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.fetches.WithLabelValues("ip", domain.ErrTimeout.Code)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheLookups.WithLabelValues(domain.CacheHit)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.linkChecks.WithLabelValues(domain.LinkBroken)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.linkChecksInFlight))
+	assert.Contains(t, scrape(t, m), `webanalyzer_analysis_phase_duration_seconds_count{phase="fetch"} 3`)
+}
+
+func TestHandler(t *testing.T) {
+	m := New()
+	m.RegisterQueue(stubHostLimitReporter{{Host: "a.com", Waiting: 2, QueuedRequests: 20}, {Host: "b.com", Waiting: 3, QueuedRequests: 30}})
+	m.ObserveRequest("/analyze", http.MethodPost, http.StatusOK, 120*time.Millisecond)
+	m.ObserveRequest("", http.MethodGet, http.StatusNotFound, time.Millisecond)
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `webanalyzer_http_requests_total{method="POST",route="/analyze",status="200"} 1`)
+	assert.Contains(t, body, `webanalyzer_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, "webanalyzer_outbound_queue_depth 5")
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestObserveReload(t *testing.T) {
+	m := New()
+	m.ObserveReload("sighup", "failed")
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.lastReloadTime))
+
+	m.ObserveReload("file", "applied")
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.reloads.WithLabelValues("sighup", "failed")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.reloads.WithLabelValues("file", "applied")))
+	assert.Greater(t, testutil.ToFloat64(m.lastReloadTime), 0.0)
+}
+
+func TestHostClass(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://example.com/page", "domain"},
+		{"https://93.184.216.34", "ip"},
+		{"https://[2606:2800:220:1::]", "ip"},
+		{"http://localhost:8080", "private"},
+		{"http://10.1.2.3", "private"},
+		{"http://[::1]", "private"},
+		{"http://printer.local", "private"},
+		{"not a url", "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hostClass(tt.url))
+		})
+	}
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(w.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
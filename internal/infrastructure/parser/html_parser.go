@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"context"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
 	"go.uber.org/zap"
 	"net/url"
 	"strings"
@@ -23,8 +25,8 @@ func NewHTMLParser(logger *zap.Logger) *htmlParser {
 // - HTML5: <!DOCTYPE html>
 // - HTML 4.01: Contains "HTML 4.01" in the DOCTYPE
 // - XHTML: Contains "XHTML" in the DOCTYPE
-func (p *htmlParser) GetHTMLVersion(doc string) string {
-	p.logger.Info("func: GetHTMLVersion started")
+func (p *htmlParser) GetHTMLVersion(ctx context.Context, doc string) string {
+	logging.FromContext(ctx, p.logger).Debug("func: GetHTMLVersion started")
 	// First check for HTML5's simple DOCTYPE
 	if strings.Contains(doc, "<!DOCTYPE html>") ||
 		strings.Contains(doc, "<!doctype html>") {
@@ -47,8 +49,8 @@ func (p *htmlParser) GetHTMLVersion(doc string) string {
 	}
 }
 
-func (p *htmlParser) GetTitle(doc string) string {
-	p.logger.Info("func: GetTitle started")
+func (p *htmlParser) GetTitle(ctx context.Context, doc string) string {
+	logging.FromContext(ctx, p.logger).Debug("func: GetTitle started")
 	docReader := strings.NewReader(doc)
 	docParsed, err := goquery.NewDocumentFromReader(docReader)
 	if err != nil {
@@ -57,8 +59,8 @@ func (p *htmlParser) GetTitle(doc string) string {
 	return docParsed.Find("title").First().Text()
 }
 
-func (p *htmlParser) CountHeadings(doc string) domain.HeadingCount {
-	p.logger.Info("func: CountHeadings started")
+func (p *htmlParser) CountHeadings(ctx context.Context, doc string) domain.HeadingCount {
+	logging.FromContext(ctx, p.logger).Debug("func: CountHeadings started")
 	docReader := strings.NewReader(doc)
 	docParsed, err := goquery.NewDocumentFromReader(docReader)
 	if err != nil {
@@ -89,8 +91,8 @@ func (p *htmlParser) CountHeadings(doc string) domain.HeadingCount {
 	return headings
 }
 
-func (p *htmlParser) AnalyzeLinks(doc string, baseURL string) domain.LinkAnalysis {
-	p.logger.Info("func: AnalyzeLinks started")
+func (p *htmlParser) AnalyzeLinks(ctx context.Context, doc string, baseURL string) domain.LinkAnalysis {
+	logging.FromContext(ctx, p.logger).Debug("func: AnalyzeLinks started")
 	docReader := strings.NewReader(doc)
 	docParsed, err := goquery.NewDocumentFromReader(docReader)
 	if err != nil {
@@ -129,8 +131,50 @@ func (p *htmlParser) AnalyzeLinks(doc string, baseURL string) domain.LinkAnalysi
 	return analysis
 }
 
-func (p *htmlParser) HasLoginForm(doc string) bool {
-	p.logger.Info("func: HasLoginForm started")
+// ExtractLinks resolves every anchor against baseURL and keeps the distinct
+// http and https targets. Fragments are dropped, since they point into the
+// same resource.
+func (p *htmlParser) ExtractLinks(ctx context.Context, doc string, baseURL string) []string {
+	logging.FromContext(ctx, p.logger).Debug("func: ExtractLinks started")
+	docReader := strings.NewReader(doc)
+	docParsed, err := goquery.NewDocumentFromReader(docReader)
+	if err != nil {
+		return nil
+	}
+
+	baseURLParsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil
+	}
+
+	var links []string
+	seen := make(map[string]bool)
+	docParsed.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		linkURL, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+
+		linkURL = baseURLParsed.ResolveReference(linkURL)
+		if (linkURL.Scheme != "http" && linkURL.Scheme != "https") || linkURL.Host == "" {
+			return
+		}
+		linkURL.Fragment = ""
+		linkURL.RawFragment = ""
+
+		link := linkURL.String()
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	})
+
+	return links
+}
+
+func (p *htmlParser) HasLoginForm(ctx context.Context, doc string) bool {
+	logging.FromContext(ctx, p.logger).Debug("func: HasLoginForm started")
 	docReader := strings.NewReader(doc)
 	docParsed, err := goquery.NewDocumentFromReader(docReader)
 	if err != nil {
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -62,7 +63,7 @@ func TestHTMLParser_GetHTMLVersion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := parser.GetHTMLVersion(tt.html)
+			result := parser.GetHTMLVersion(context.Background(), tt.html)
 			assert.Equal(t, tt.expected, result,
 				"For HTML: %s\nExpected: %s\nGot: %s",
 				tt.html, tt.expected, result)
@@ -97,7 +98,7 @@ func TestHTMLParser_CountHeadings(t *testing.T) {
 	}
 
 	parser := NewHTMLParser(logger)
-	result := parser.CountHeadings(html)
+	result := parser.CountHeadings(context.Background(), html)
 	assert.Equal(t, expected, result)
 }
 
@@ -136,8 +137,30 @@ func TestHTMLParser_HasLoginForm(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := parser.HasLoginForm(tt.html)
+			result := parser.HasLoginForm(context.Background(), tt.html)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
+
+func TestHTMLParser_ExtractLinks(t *testing.T) {
+	html := `
+        <a href="/about">About</a>
+        <a href="/about#team">Team</a>
+        <a href="https://other.example.com/page">Other</a>
+        <a href="mailto:team@example.com">Mail</a>
+        <a href="javascript:void(0)">Menu</a>
+        <a href="#top">Top</a>
+        <a href="//cdn.example.com/file.pdf">File</a>
+    `
+
+	parser := NewHTMLParser(zap.NewNop())
+	result := parser.ExtractLinks(context.Background(), html, "https://example.com/index.html")
+
+	assert.Equal(t, []string{
+		"https://example.com/about",
+		"https://other.example.com/page",
+		"https://example.com/index.html",
+		"https://cdn.example.com/file.pdf",
+	}, result)
+}
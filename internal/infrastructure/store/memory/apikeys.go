@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+)
+
+// apiKeyStore keeps API keys in memory. Keys and usage are lost on restart,
+// so it suits single instance deployments that bootstrap keys from config.
+type apiKeyStore struct {
+	mu     sync.RWMutex
+	keys   map[string]domain.APIKey
+	hashes map[string]string
+	// usage counts requests per key for the current day only
+	usage map[string]dailyUsage
+}
+
+type dailyUsage struct {
+	day   string
+	count int64
+}
+
+func NewAPIKeyStore() ports.APIKeyStore {
+	return &apiKeyStore{
+		keys:   make(map[string]domain.APIKey),
+		hashes: make(map[string]string),
+		usage:  make(map[string]dailyUsage),
+	}
+}
+
+func (s *apiKeyStore) Create(ctx context.Context, key domain.APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[key.ID] = key
+	s.hashes[key.Hash] = key.ID
+	return nil
+}
+
+func (s *apiKeyStore) Get(ctx context.Context, id string) (*domain.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return &key, nil
+}
+
+func (s *apiKeyStore) FindByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.hashes[hash]
+	if !ok {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	key := s.keys[id]
+	return &key, nil
+}
+
+func (s *apiKeyStore) List(ctx context.Context) ([]domain.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]domain.APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+func (s *apiKeyStore) Revoke(ctx context.Context, id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return domain.ErrAPIKeyNotFound
+	}
+	if key.RevokedAt == nil {
+		key.RevokedAt = &at
+		s.keys[id] = key
+	}
+	return nil
+}
+
+func (s *apiKeyStore) IncrementUsage(ctx context.Context, id string, day string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := s.usage[id]
+	if u.day != day {
+		u = dailyUsage{day: day}
+	}
+	u.count++
+	s.usage[id] = u
+	return u.count, nil
+}
+
+func (s *apiKeyStore) DecrementUsage(ctx context.Context, id string, day string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u := s.usage[id]; u.day == day && u.count > 0 {
+		u.count--
+		s.usage[id] = u
+	}
+	return nil
+}
+
+func (s *apiKeyStore) Usage(ctx context.Context, id string, day string) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if u := s.usage[id]; u.day == day {
+		return u.count, nil
+	}
+	return 0, nil
+}
+
+// Ping always succeeds once the lock can be taken, since the data is in process
+func (s *apiKeyStore) Ping(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return ctx.Err()
+}
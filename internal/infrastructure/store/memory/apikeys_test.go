@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+)
+
+func TestAPIKeyStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewAPIKeyStore()
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, store.Create(ctx, domain.APIKey{ID: "b", Name: "second", Hash: "hash-b", CreatedAt: created.Add(time.Hour)}))
+	assert.NoError(t, store.Create(ctx, domain.APIKey{ID: "a", Name: "first", Hash: "hash-a", CreatedAt: created}))
+
+	key, err := store.FindByHash(ctx, "hash-b")
+	assert.NoError(t, err)
+	assert.Equal(t, "second", key.Name)
+
+	_, err = store.FindByHash(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+
+	keys, err := store.List(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, keys, 2) {
+		assert.Equal(t, "a", keys[0].ID)
+		assert.Equal(t, "b", keys[1].ID)
+	}
+
+	assert.NoError(t, store.Revoke(ctx, "a", created))
+	key, err = store.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, key.Revoked())
+	assert.ErrorIs(t, store.Revoke(ctx, "missing", created), domain.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyStore_IncrementUsage(t *testing.T) {
+	ctx := context.Background()
+	store := NewAPIKeyStore()
+
+	for i := int64(1); i <= 3; i++ {
+		count, err := store.IncrementUsage(ctx, "a", "2026-01-02")
+		assert.NoError(t, err)
+		assert.Equal(t, i, count)
+	}
+
+	// Usage starts over on a new day and is tracked per key
+	count, _ := store.IncrementUsage(ctx, "a", "2026-01-03")
+	assert.Equal(t, int64(1), count)
+	count, _ = store.IncrementUsage(ctx, "b", "2026-01-03")
+	assert.Equal(t, int64(1), count)
+
+	count, err := store.Usage(ctx, "a", "2026-01-03")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	count, _ = store.Usage(ctx, "a", "2026-01-02")
+	assert.Equal(t, int64(0), count)
+	count, _ = store.Usage(ctx, "c", "2026-01-03")
+	assert.Equal(t, int64(0), count)
+}
+
+func TestAPIKeyStore_DecrementUsage(t *testing.T) {
+	ctx := context.Background()
+	store := NewAPIKeyStore()
+
+	store.IncrementUsage(ctx, "a", "2026-01-02")
+	store.IncrementUsage(ctx, "a", "2026-01-02")
+	assert.NoError(t, store.DecrementUsage(ctx, "a", "2026-01-02"))
+	count, _ := store.Usage(ctx, "a", "2026-01-02")
+	assert.Equal(t, int64(1), count)
+
+	// Past days and unused keys are left alone and usage never goes negative
+	assert.NoError(t, store.DecrementUsage(ctx, "a", "2026-01-01"))
+	assert.NoError(t, store.DecrementUsage(ctx, "b", "2026-01-02"))
+	assert.NoError(t, store.DecrementUsage(ctx, "a", "2026-01-02"))
+	assert.NoError(t, store.DecrementUsage(ctx, "a", "2026-01-02"))
+	count, _ = store.Usage(ctx, "a", "2026-01-02")
+	assert.Equal(t, int64(0), count)
+	count, _ = store.Usage(ctx, "b", "2026-01-02")
+	assert.Equal(t, int64(0), count)
+}
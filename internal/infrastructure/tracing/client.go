@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/suraif16/webpage-analyzer/internal/infrastructure/tracing"
+
+// tracedClient records a span for every fetch and link check. It wraps the
+// base client, so each retry attempt gets its own span.
+type tracedClient struct {
+	next ports.HTTPClient
+}
+
+func TraceClient(next ports.HTTPClient) ports.HTTPClient {
+	return &tracedClient{next: next}
+}
+
+func (c *tracedClient) FetchPage(ctx context.Context, rawURL string, opts domain.FetchOptions) (*domain.FetchResult, error) {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "FetchPage",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("url.full", redactURL(rawURL))))
+	defer span.End()
+
+	result, err := c.next.FetchPage(ctx, rawURL, opts)
+	if err != nil {
+		RecordError(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("http.response.status_code", result.StatusCode),
+		attribute.Int("webanalyzer.redirects", len(result.Redirects.Chain)),
+		attribute.Int("webanalyzer.body_size", len(result.Body)),
+	)
+	if result.Cache != nil {
+		span.SetAttributes(attribute.String("webanalyzer.cache_status", result.Cache.Status))
+	}
+	return result, nil
+}
+
+func (c *tracedClient) CheckLink(ctx context.Context, rawURL string) domain.LinkCheckResult {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "CheckLink",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("url.full", redactURL(rawURL))))
+	defer span.End()
+
+	result := c.next.CheckLink(ctx, rawURL)
+	span.SetAttributes(
+		attribute.String("webanalyzer.link_status", result.Status),
+		attribute.Int("http.response.status_code", result.StatusCode),
+		attribute.String("http.request.method", result.Method),
+	)
+	if result.Status == domain.LinkBroken {
+		span.SetStatus(codes.Error, result.Error)
+	}
+	return result
+}
+
+// RecordError marks span as failed, tagging it with the API error code when
+// err carries one
+func RecordError(span trace.Span, err error) {
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		span.SetAttributes(attribute.String("error.type", apiErr.Code))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// redactURL drops credentials from URLs before they are recorded
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNew(t *testing.T) {
+	logger, err := New("warn")
+	assert.NoError(t, err)
+	assert.False(t, logger.Core().Enabled(zapcore.InfoLevel))
+	assert.True(t, logger.Core().Enabled(zapcore.WarnLevel))
+
+	_, err = New("verbose")
+	assert.Error(t, err)
+}
+
+func TestNewAtLevel(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.ErrorLevel)
+	logger, err := NewAtLevel(level)
+	assert.NoError(t, err)
+	assert.False(t, logger.Core().Enabled(zapcore.InfoLevel))
+
+	level.SetLevel(zapcore.DebugLevel)
+	assert.True(t, logger.Core().Enabled(zapcore.DebugLevel))
+}
+
+func TestFromContext(t *testing.T) {
+	fallback := zap.NewNop()
+	assert.Same(t, fallback, FromContext(context.Background(), fallback))
+
+	scoped := zap.NewExample()
+	ctx := WithLogger(context.Background(), scoped)
+	assert.Same(t, scoped, FromContext(ctx, fallback))
+}
+
+func TestRequestID(t *testing.T) {
+	assert.Equal(t, "", RequestID(context.Background()))
+	assert.Equal(t, "abc", RequestID(WithRequestID(context.Background(), "abc")))
+}
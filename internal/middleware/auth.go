@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/ports"
+	"github.com/suraif16/webpage-analyzer/internal/httperr"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	// APIKeyHeader carries the API key when it is not sent as a bearer token
+	APIKeyHeader = "X-API-Key"
+	// apiKeyContextKey stores the authenticated key in the gin context
+	apiKeyContextKey = "apiKey"
+)
+
+// Auth rejects requests without a valid API key. The authenticated key is
+// available via APIKeyFrom; its limits are enforced by KeyLimit.
+func Auth(keys ports.APIKeyManager, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, err := keys.Authenticate(c.Request.Context(), apiKeyFromRequest(c))
+		if err != nil {
+			var apiErr *domain.APIError
+			if !errors.As(err, &apiErr) {
+				logging.FromContext(c.Request.Context(), logger).Error("api key authentication failed", zap.Error(err))
+				apiErr = domain.ErrInternalServer
+			}
+			httperr.Abort(c, apiErr)
+			return
+		}
+
+		c.Set(apiKeyContextKey, key)
+		c.Next()
+	}
+}
+
+// KeyLimit enforces the rate limit and daily quota of the key a request was
+// authenticated with. The quota is taken before the rest of the chain runs and
+// refunded when a later limit or validation rejects the request. It must run
+// after Auth.
+func KeyLimit(keys ports.APIKeyManager, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := APIKeyFrom(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := keys.Allow(ctx, *key); err != nil {
+			var apiErr *domain.APIError
+			if !errors.As(err, &apiErr) {
+				logging.FromContext(ctx, logger).Error("api key limit check failed", zap.Error(err))
+				apiErr = domain.ErrInternalServer
+			}
+			httperr.Abort(c, apiErr)
+			return
+		}
+
+		c.Next()
+
+		if !rejected(c.Writer.Status()) {
+			return
+		}
+		// The client may be gone by now, which must not keep the refund from happening
+		if err := keys.Refund(context.WithoutCancel(ctx), *key); err != nil {
+			logging.FromContext(ctx, logger).Error("refunding api key usage failed", zap.Error(err))
+		}
+	}
+}
+
+// rejected reports whether a response refused the request instead of serving
+// it, such as a failed validation or an exceeded limit
+func rejected(status int) bool {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden,
+		http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
+
+// RequireRole only lets through requests authenticated with a key of the role.
+// It must run after Auth.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := APIKeyFrom(c)
+		if !ok || key.Role != role {
+			httperr.Abort(c, domain.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// APIKeyFrom returns the key the request was authenticated with
+func APIKeyFrom(c *gin.Context) (*domain.APIKey, bool) {
+	value, ok := c.Get(apiKeyContextKey)
+	if !ok {
+		return nil, false
+	}
+	key, ok := value.(*domain.APIKey)
+	return key, ok
+}
+
+// apiKeyFromRequest reads the key from the X-API-Key header or a bearer token
+func apiKeyFromRequest(c *gin.Context) string {
+	if key := c.GetHeader(APIKeyHeader); key != "" {
+		return key
+	}
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// stubKeyManager authenticates a fixed set of keys, fails Allow with err and
+// counts usage per key ID when used is set
+type stubKeyManager struct {
+	keys map[string]*domain.APIKey
+	err  error
+	used map[string]int
+}
+
+func (s stubKeyManager) Create(ctx context.Context, req domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error) {
+	return nil, nil
+}
+
+func (s stubKeyManager) Register(ctx context.Context, name, rawKey, role string) (*domain.APIKey, error) {
+	return nil, nil
+}
+
+func (s stubKeyManager) List(ctx context.Context) ([]domain.APIKey, error) {
+	return nil, nil
+}
+
+func (s stubKeyManager) Revoke(ctx context.Context, id string) error {
+	return nil
+}
+
+func (s stubKeyManager) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	key, ok := s.keys[rawKey]
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+	return key, nil
+}
+
+func (s stubKeyManager) Allow(ctx context.Context, key domain.APIKey) error {
+	if s.err == nil && s.used != nil {
+		s.used[key.ID]++
+	}
+	return s.err
+}
+
+func (s stubKeyManager) Refund(ctx context.Context, key domain.APIKey) error {
+	if s.used != nil {
+		s.used[key.ID]--
+	}
+	return nil
+}
+
+func TestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &domain.APIKey{ID: "k1", Name: "ci", Role: domain.RoleClient}
+	admin := &domain.APIKey{ID: "k2", Name: "ops", Role: domain.RoleAdmin}
+	keys := map[string]*domain.APIKey{"client-key": client, "admin-key": admin}
+
+	tests := []struct {
+		name           string
+		manager        stubKeyManager
+		path           string
+		headers        map[string]string
+		expectedStatus int
+		expectedRetry  string
+	}{
+		{
+			name:           "Missing key",
+			manager:        stubKeyManager{keys: keys},
+			path:           "/analyze",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Invalid key",
+			manager:        stubKeyManager{keys: keys},
+			path:           "/analyze",
+			headers:        map[string]string{"X-API-Key": "wrong"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Key in header",
+			manager:        stubKeyManager{keys: keys},
+			path:           "/analyze",
+			headers:        map[string]string{"X-API-Key": "client-key"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Bearer token",
+			manager:        stubKeyManager{keys: keys},
+			path:           "/analyze",
+			headers:        map[string]string{"Authorization": "Bearer client-key"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Rate limited",
+			manager:        stubKeyManager{keys: keys, err: domain.ErrRateLimited.WithRetryAfter(1500 * time.Millisecond)},
+			path:           "/analyze",
+			headers:        map[string]string{"X-API-Key": "client-key"},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedRetry:  "2",
+		},
+		{
+			name:           "Client key on admin route",
+			manager:        stubKeyManager{keys: keys},
+			path:           "/admin",
+			headers:        map[string]string{"X-API-Key": "client-key"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Admin key on admin route",
+			manager:        stubKeyManager{keys: keys},
+			path:           "/admin",
+			headers:        map[string]string{"X-API-Key": "admin-key"},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Auth(tt.manager, zap.NewNop()), KeyLimit(tt.manager, zap.NewNop()))
+			r.GET("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+			r.GET("/admin", RequireRole(domain.RoleAdmin), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedRetry, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestKeyLimit_RecordsServedRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	manager := stubKeyManager{
+		keys: map[string]*domain.APIKey{"client-key": {ID: "k1", Name: "ci"}},
+		used: make(map[string]int),
+	}
+	r := gin.New()
+	r.Use(Auth(manager, zap.NewNop()), KeyLimit(manager, zap.NewNop()))
+	r.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/upstream-error", func(c *gin.Context) { c.Status(http.StatusBadGateway) })
+	r.GET("/invalid", func(c *gin.Context) { c.Status(http.StatusBadRequest) })
+	r.GET("/limited", func(c *gin.Context) { c.AbortWithStatus(http.StatusTooManyRequests) })
+
+	for _, path := range []string{"/ok", "/upstream-error", "/invalid", "/limited"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-API-Key", "client-key")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Only the requests that were served count towards the quota
+	assert.Equal(t, 2, manager.used["k1"])
+}
+
+func TestLoggerIncludesAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.InfoLevel)
+
+	manager := stubKeyManager{keys: map[string]*domain.APIKey{"client-key": {ID: "k1", Name: "ci"}}}
+	r := gin.New()
+	r.Use(Logger(zap.New(core)), Auth(manager, zap.NewNop()))
+	r.GET("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/analyze", nil)
+	req.Header.Set("X-API-Key", "client-key")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.FilterMessage("request completed").All()
+	if assert.Len(t, entries, 1) {
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "k1", fields["api_key_id"])
+		assert.Equal(t, "ci", fields["api_key_name"])
+	}
+}
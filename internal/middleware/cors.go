@@ -1,20 +1,202 @@
 package middleware
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.uber.org/zap"
 )
 
-func CORS() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, Authorization")
+// CORSConfig controls which browser origins may call the API
+type CORSConfig struct {
+	// AllowedOrigins holds exact origins such as "https://app.example.com",
+	// wildcard subdomain patterns such as "https://*.example.com", or "*"
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// originPattern matches an origin exactly or, when wildcard, any subdomain
+type originPattern struct {
+	exact    string
+	prefix   string
+	suffix   string
+	wildcard bool
+}
+
+func (p originPattern) match(origin string) bool {
+	if !p.wildcard {
+		return origin == p.exact
+	}
+	if !strings.HasPrefix(origin, p.prefix) || !strings.HasSuffix(origin, p.suffix) {
+		return false
+	}
+	sub := origin[len(p.prefix) : len(origin)-len(p.suffix)]
+	return sub != "" && !strings.ContainsAny(sub, "/:@")
+}
+
+// corsRules is a CORSConfig compiled for matching
+type corsRules struct {
+	cfg      CORSConfig
+	patterns []originPattern
+	allowAll bool
+	methods  string
+	headers  string
+	exposed  string
+	maxAge   string
+}
+
+func compileCORS(cfg CORSConfig) *corsRules {
+	rules := &corsRules{
+		cfg:     cfg,
+		methods: strings.Join(cfg.AllowedMethods, ", "),
+		headers: strings.Join(cfg.AllowedHeaders, ", "),
+		exposed: strings.Join(cfg.ExposedHeaders, ", "),
+		maxAge:  strconv.Itoa(int(cfg.MaxAge.Seconds())),
+	}
+	for _, o := range cfg.AllowedOrigins {
+		o = strings.ToLower(strings.TrimSuffix(o, "/"))
+		switch {
+		case o == "*":
+			rules.allowAll = true
+		case strings.Contains(o, "://*."):
+			i := strings.Index(o, "*")
+			rules.patterns = append(rules.patterns, originPattern{prefix: o[:i], suffix: o[i+1:], wildcard: true})
+		default:
+			rules.patterns = append(rules.patterns, originPattern{exact: o})
+		}
+	}
+	return rules
+}
+
+func (r *corsRules) allowed(origin string) bool {
+	if r.allowAll {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	for _, p := range r.patterns {
+		if p.match(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS answers preflight requests and echoes allowed origins back with
+// Vary: Origin. Requests from other origins get no CORS headers, so browsers
+// block them; rejected preflights get 403.
+func CORS(cfg CORSConfig, logger *zap.Logger) gin.HandlerFunc {
+	return NewCORSPolicy(cfg, logger).Handler
+}
+
+// CORSPolicy is a CORS middleware whose configuration can be replaced while it
+// serves requests
+type CORSPolicy struct {
+	current atomic.Pointer[corsRules]
+	logger  *zap.Logger
+}
+
+func NewCORSPolicy(cfg CORSConfig, logger *zap.Logger) *CORSPolicy {
+	p := &CORSPolicy{logger: logger}
+	p.Update(cfg)
+	return p
+}
+
+// Update swaps in a new configuration for subsequent requests
+func (p *CORSPolicy) Update(cfg CORSConfig) {
+	p.current.Store(compileCORS(cfg))
+}
+
+// Handler applies the current configuration
+func (p *CORSPolicy) Handler(c *gin.Context) {
+	rules := p.current.Load()
+	cfg := rules.cfg
+	logger := p.logger
+
+	origin := c.GetHeader("Origin")
+	c.Writer.Header().Add("Vary", "Origin")
+	if origin == "" {
+		c.Next()
+		return
+	}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+	preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+	if !rules.allowed(origin) {
+		logging.FromContext(c.Request.Context(), logger).Warn("cors origin rejected",
+			zap.String("origin", origin),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path))
+		if preflight {
+			c.AbortWithStatus(http.StatusForbidden)
 			return
 		}
+		c.Next()
+		return
+	}
+
+	h := c.Writer.Header()
+	// A literal * is not allowed together with credentials
+	if rules.allowAll && !cfg.AllowCredentials {
+		h.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+	}
+	if cfg.AllowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
 
+	if !preflight {
+		if rules.exposed != "" {
+			h.Set("Access-Control-Expose-Headers", rules.exposed)
+		}
 		c.Next()
+		return
+	}
+
+	method := c.GetHeader("Access-Control-Request-Method")
+	if !containsFold(cfg.AllowedMethods, method) {
+		logging.FromContext(c.Request.Context(), logger).Warn("cors preflight rejected",
+			zap.String("origin", origin),
+			zap.String("reason", "method not allowed"),
+			zap.String("requested_method", method))
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	for _, name := range strings.Split(c.GetHeader("Access-Control-Request-Headers"), ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !containsFold(cfg.AllowedHeaders, name) {
+			logging.FromContext(c.Request.Context(), logger).Warn("cors preflight rejected",
+				zap.String("origin", origin),
+				zap.String("reason", "header not allowed"),
+				zap.String("requested_header", name))
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+
+	h.Set("Access-Control-Allow-Methods", rules.methods)
+	h.Set("Access-Control-Allow-Headers", rules.headers)
+	if cfg.MaxAge > 0 {
+		h.Set("Access-Control-Max-Age", rules.maxAge)
+	}
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
 	}
+	return false
 }
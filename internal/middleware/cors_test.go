@@ -0,0 +1,208 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := CORSConfig{
+		AllowedOrigins: []string{"http://localhost:3000", "https://*.example.com"},
+		AllowedMethods: []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "X-API-Key"},
+		ExposedHeaders: []string{"Retry-After"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	tests := []struct {
+		name            string
+		cfg             CORSConfig
+		method          string
+		headers         map[string]string
+		expectedStatus  int
+		expectedOrigin  string
+		expectedHeaders map[string]string
+		expectRejection bool
+	}{
+		{
+			name:           "Exact origin",
+			cfg:            cfg,
+			method:         http.MethodPost,
+			headers:        map[string]string{"Origin": "http://localhost:3000"},
+			expectedStatus: http.StatusOK,
+			expectedOrigin: "http://localhost:3000",
+			expectedHeaders: map[string]string{
+				"Vary":                          "Origin",
+				"Access-Control-Expose-Headers": "Retry-After",
+			},
+		},
+		{
+			name:           "Wildcard subdomain",
+			cfg:            cfg,
+			method:         http.MethodPost,
+			headers:        map[string]string{"Origin": "https://app.eu.example.com"},
+			expectedStatus: http.StatusOK,
+			expectedOrigin: "https://app.eu.example.com",
+		},
+		{
+			name:            "Wildcard does not match apex",
+			cfg:             cfg,
+			method:          http.MethodPost,
+			headers:         map[string]string{"Origin": "https://example.com"},
+			expectedStatus:  http.StatusOK,
+			expectRejection: true,
+		},
+		{
+			name:            "Wildcard does not match other scheme",
+			cfg:             cfg,
+			method:          http.MethodPost,
+			headers:         map[string]string{"Origin": "http://app.example.com"},
+			expectedStatus:  http.StatusOK,
+			expectRejection: true,
+		},
+		{
+			name:            "Lookalike domain",
+			cfg:             cfg,
+			method:          http.MethodPost,
+			headers:         map[string]string{"Origin": "https://app.example.com.evil.io"},
+			expectedStatus:  http.StatusOK,
+			expectRejection: true,
+		},
+		{
+			name:           "No origin",
+			cfg:            cfg,
+			method:         http.MethodPost,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Preflight",
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "content-type, x-api-key",
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedOrigin: "https://app.example.com",
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Methods": "GET, POST, OPTIONS",
+				"Access-Control-Allow-Headers": "Content-Type, X-API-Key",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name:   "Preflight from unknown origin",
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.io",
+				"Access-Control-Request-Method": "POST",
+			},
+			expectedStatus:  http.StatusForbidden,
+			expectRejection: true,
+		},
+		{
+			name:   "Preflight with disallowed method",
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "http://localhost:3000",
+				"Access-Control-Request-Method": "PUT",
+			},
+			expectedStatus:  http.StatusForbidden,
+			expectedOrigin:  "http://localhost:3000",
+			expectRejection: true,
+		},
+		{
+			name:   "Preflight with disallowed header",
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "http://localhost:3000",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "X-Debug",
+			},
+			expectedStatus:  http.StatusForbidden,
+			expectedOrigin:  "http://localhost:3000",
+			expectRejection: true,
+		},
+		{
+			name:           "Any origin",
+			cfg:            CORSConfig{AllowedOrigins: []string{"*"}},
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://anything.io"},
+			expectedStatus: http.StatusOK,
+			expectedOrigin: "*",
+		},
+		{
+			name:           "Any origin with credentials echoes origin",
+			cfg:            CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://anything.io"},
+			expectedStatus: http.StatusOK,
+			expectedOrigin: "https://anything.io",
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+
+			r := gin.New()
+			r.Use(CORS(tt.cfg, zap.New(core)))
+			r.Any("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/analyze", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			for k, v := range tt.expectedHeaders {
+				assert.Equal(t, v, w.Header().Get(k), k)
+			}
+			assert.Equal(t, tt.expectRejection, logs.Len() > 0)
+		})
+	}
+}
+
+func TestCORSPolicy_Update(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cors := NewCORSPolicy(CORSConfig{AllowedOrigins: []string{"https://old.example.com"}}, zap.NewNop())
+	r := gin.New()
+	r.Use(cors.Handler)
+	r.GET("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	allowOrigin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/analyze", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	assert.Equal(t, "https://old.example.com", allowOrigin("https://old.example.com"))
+	assert.Equal(t, "", allowOrigin("https://new.example.com"))
+
+	cors.Update(CORSConfig{AllowedOrigins: []string{"https://new.example.com"}})
+
+	assert.Equal(t, "", allowOrigin("https://old.example.com"))
+	assert.Equal(t, "https://new.example.com", allowOrigin("https://new.example.com"))
+}
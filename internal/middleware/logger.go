@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
 	"go.uber.org/zap"
 	"time"
 )
@@ -16,12 +17,19 @@ func Logger(log *zap.Logger) gin.HandlerFunc {
 		latency := time.Since(start)
 		statusCode := c.Writer.Status()
 
-		log.Info("request completed",
+		fields := []zap.Field{
 			zap.String("path", path),
 			zap.Int("status", statusCode),
 			zap.Duration("latency", latency),
 			zap.String("ip", c.ClientIP()),
 			zap.String("method", c.Request.Method),
-		)
+		}
+		if key, ok := APIKeyFrom(c); ok {
+			fields = append(fields,
+				zap.String("api_key_id", key.ID),
+				zap.String("api_key_name", key.Name))
+		}
+
+		logging.FromContext(c.Request.Context(), log).Info("request completed", fields...)
 	}
 }
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type observedRequest struct {
+	route  string
+	method string
+	status int
+}
+
+type recordingObserver struct {
+	requests []observedRequest
+}
+
+func (r *recordingObserver) ObserveRequest(route, method string, status int, d time.Duration) {
+	r.requests = append(r.requests, observedRequest{route: route, method: method, status: status})
+}
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	observer := &recordingObserver{}
+	r := gin.New()
+	r.Use(Metrics(observer))
+	r.DELETE("/admin/api-keys/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodDelete, "/admin/api-keys/abc", nil),
+		httptest.NewRequest("PROPFIND", "/unknown", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []observedRequest{
+		{route: "/admin/api-keys/:id", method: http.MethodDelete, status: http.StatusNoContent},
+		{route: "", method: "OTHER", status: http.StatusNotFound},
+	}, observer.requests)
+}
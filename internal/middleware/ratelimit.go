@@ -0,0 +1,245 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/httperr"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// clientIdleTTL is how long a client's bucket is kept after its last request
+const clientIdleTTL = 10 * time.Minute
+
+// RateLimitPolicy describes a token bucket: clients may send Burst requests at
+// once, refilled at RequestsPerMinute. A zero rate disables the limit.
+type RateLimitPolicy struct {
+	Name              string
+	RequestsPerMinute int
+	Burst             int
+}
+
+type clientBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter holds one bucket per client
+type rateLimiter struct {
+	policy    RateLimitPolicy
+	mu        sync.Mutex
+	clients   map[string]*clientBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// RateLimit limits requests per API key, or per client IP for requests made
+// without one. Every response carries RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers; rejected requests get 429 with Retry-After. Each
+// call creates independent buckets, so stricter limits for expensive routes
+// can be stacked on top of a general one.
+func RateLimit(policy RateLimitPolicy, logger *zap.Logger) gin.HandlerFunc {
+	return NewRateLimiter(policy, logger).Handler
+}
+
+// RateLimiter is a RateLimit whose policy can be replaced while it serves
+// requests
+type RateLimiter struct {
+	current atomic.Pointer[rateLimiter]
+	logger  *zap.Logger
+}
+
+func NewRateLimiter(policy RateLimitPolicy, logger *zap.Logger) *RateLimiter {
+	r := &RateLimiter{logger: logger}
+	r.Update(policy)
+	return r
+}
+
+// Update swaps in a new policy. Buckets are not carried over, so every client
+// starts with a full bucket under the new limits.
+func (r *RateLimiter) Update(policy RateLimitPolicy) {
+	if policy.RequestsPerMinute <= 0 {
+		r.current.Store(nil)
+		return
+	}
+	if policy.Burst < 1 {
+		policy.Burst = 1
+	}
+	r.current.Store(&rateLimiter{
+		policy:  policy,
+		clients: make(map[string]*clientBucket),
+		now:     time.Now,
+	})
+}
+
+// Handler enforces the current policy
+func (r *RateLimiter) Handler(c *gin.Context) {
+	l := r.current.Load()
+	if l == nil {
+		c.Next()
+		return
+	}
+
+	client := clientID(c)
+	now := l.now()
+	limiter := l.bucket(client, now)
+
+	if wait := take(limiter, now); wait > 0 {
+		l.setHeaders(c, limiter, now)
+
+		logging.FromContext(c.Request.Context(), r.logger).Warn("request rate limited",
+			zap.String("limit", l.policy.Name),
+			zap.String("client", client),
+			zap.String("path", c.FullPath()),
+			zap.Duration("retry_after", wait))
+		httperr.Abort(c, domain.ErrRateLimited.WithRetryAfter(wait))
+		return
+	}
+
+	l.setHeaders(c, limiter, now)
+	c.Next()
+}
+
+// Take removes one request from the bucket of client, as named by ClientID,
+// and returns how long the client has to wait when the bucket is empty. It
+// lets other transports share the policy and buckets of the REST API.
+func (r *RateLimiter) Take(client string) time.Duration {
+	l := r.current.Load()
+	if l == nil {
+		return 0
+	}
+	now := l.now()
+	return take(l.bucket(client, now), now)
+}
+
+// take removes a token from limiter, returning how long until one is
+// available when there is none
+func take(limiter *rate.Limiter, now time.Time) time.Duration {
+	res := limiter.ReserveN(now, 1)
+	if wait := res.DelayFrom(now); wait > 0 {
+		res.CancelAt(now)
+		return wait
+	}
+	return 0
+}
+
+// FailureHandler enforces the current policy per client IP on failed
+// authentication only: only 401 responses take a token, and a client whose
+// bucket is empty is rejected before its key is checked. Placed ahead of Auth
+// it throttles key guessing without limiting valid keys by address.
+func (r *RateLimiter) FailureHandler(c *gin.Context) {
+	l := r.current.Load()
+	if l == nil {
+		c.Next()
+		return
+	}
+
+	client := ClientID(nil, c.ClientIP())
+	if wait := r.Throttled(client); wait > 0 {
+		logging.FromContext(c.Request.Context(), r.logger).Warn("client throttled after failed authentication",
+			zap.String("limit", l.policy.Name),
+			zap.String("client", client),
+			zap.String("path", c.FullPath()),
+			zap.Duration("retry_after", wait))
+		httperr.Abort(c, domain.ErrRateLimited.WithRetryAfter(wait))
+		return
+	}
+
+	c.Next()
+	if c.Writer.Status() == http.StatusUnauthorized {
+		r.Charge(client)
+	}
+}
+
+// Throttled returns how long client has to wait until its bucket holds a
+// request again, without taking one. With Charge it lets other transports
+// share the failed authentication buckets of FailureHandler.
+func (r *RateLimiter) Throttled(client string) time.Duration {
+	l := r.current.Load()
+	if l == nil {
+		return 0
+	}
+	now := l.now()
+	limiter := l.bucket(client, now)
+	if tokens := limiter.TokensAt(now); tokens < 1 {
+		return time.Duration((1 - tokens) / float64(limiter.Limit()) * float64(time.Second))
+	}
+	return 0
+}
+
+// Charge takes one request from the bucket of client, even when it is empty
+func (r *RateLimiter) Charge(client string) {
+	l := r.current.Load()
+	if l == nil {
+		return
+	}
+	now := l.now()
+	l.bucket(client, now).ReserveN(now, 1)
+}
+
+// bucket returns the limiter of a client, creating it on first use
+func (l *rateLimiter) bucket(client string, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > time.Minute {
+		l.lastSweep = now
+		for id, b := range l.clients {
+			if now.Sub(b.lastSeen) > clientIdleTTL {
+				delete(l.clients, id)
+			}
+		}
+	}
+
+	b, ok := l.clients[client]
+	if !ok {
+		b = &clientBucket{
+			limiter: rate.NewLimiter(rate.Limit(float64(l.policy.RequestsPerMinute)/60), l.policy.Burst),
+		}
+		l.clients[client] = b
+	}
+	b.lastSeen = now
+	return b.limiter
+}
+
+// setHeaders reports the bucket state using the IETF RateLimit header fields.
+// Reset is the number of seconds until the bucket is full again.
+func (l *rateLimiter) setHeaders(c *gin.Context, limiter *rate.Limiter, now time.Time) {
+	tokens := limiter.TokensAt(now)
+	remaining := int(tokens)
+	if remaining < 0 {
+		remaining = 0
+	}
+	missing := float64(l.policy.Burst) - tokens
+	reset := int(missing / float64(limiter.Limit()))
+	if float64(reset) < missing/float64(limiter.Limit()) {
+		reset++
+	}
+
+	c.Header("RateLimit-Limit", strconv.Itoa(l.policy.Burst))
+	c.Header("RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(reset))
+	c.Header("RateLimit-Policy", strconv.Itoa(l.policy.Burst)+";w=60;r="+strconv.Itoa(l.policy.RequestsPerMinute))
+}
+
+// clientID identifies the caller by API key when authenticated and by IP otherwise
+func clientID(c *gin.Context) string {
+	key, _ := APIKeyFrom(c)
+	return ClientID(key, c.ClientIP())
+}
+
+// ClientID names the rate limit bucket of a caller: its API key when there is
+// one, and its IP address otherwise
+func ClientID(key *domain.APIKey, ip string) string {
+	if key != nil {
+		return "key:" + key.ID
+	}
+	return "ip:" + ip
+}
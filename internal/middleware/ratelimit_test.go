@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name              string
+		policy            RateLimitPolicy
+		requests          []string // client IP of each request
+		expectedStatuses  []int
+		expectedRemaining []string
+	}{
+		{
+			name:              "Burst exhausted",
+			policy:            RateLimitPolicy{RequestsPerMinute: 1, Burst: 2},
+			requests:          []string{"10.0.0.1", "10.0.0.1", "10.0.0.1"},
+			expectedStatuses:  []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedRemaining: []string{"1", "0", "0"},
+		},
+		{
+			name:              "Clients limited separately",
+			policy:            RateLimitPolicy{RequestsPerMinute: 1, Burst: 1},
+			requests:          []string{"10.0.0.1", "10.0.0.2", "10.0.0.1"},
+			expectedStatuses:  []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedRemaining: []string{"0", "0", "0"},
+		},
+		{
+			name:              "Disabled",
+			policy:            RateLimitPolicy{RequestsPerMinute: 0, Burst: 1},
+			requests:          []string{"10.0.0.1", "10.0.0.1"},
+			expectedStatuses:  []int{http.StatusOK, http.StatusOK},
+			expectedRemaining: []string{"", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(RateLimit(tt.policy, zap.NewNop()))
+			r.GET("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			for i, ip := range tt.requests {
+				req := httptest.NewRequest(http.MethodGet, "/analyze", nil)
+				req.RemoteAddr = ip + ":1234"
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+
+				assert.Equal(t, tt.expectedStatuses[i], w.Code, "request %d", i)
+				assert.Equal(t, tt.expectedRemaining[i], w.Header().Get("RateLimit-Remaining"), "request %d", i)
+				if w.Code == http.StatusTooManyRequests {
+					assert.Equal(t, "60", w.Header().Get("Retry-After"))
+					assert.Contains(t, w.Body.String(), "RATE_LIMITED")
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(RateLimit(RateLimitPolicy{RequestsPerMinute: 30, Burst: 10}, zap.NewNop()))
+	r.GET("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/analyze", nil))
+
+	assert.Equal(t, "10", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "9", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "10;w=60;r=30", w.Header().Get("RateLimit-Policy"))
+}
+
+func TestRateLimitByAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	keys := stubKeyManager{keys: map[string]*domain.APIKey{
+		"first":  {ID: "k1", Role: domain.RoleClient},
+		"second": {ID: "k2", Role: domain.RoleClient},
+	}}
+
+	r := gin.New()
+	r.Use(Auth(keys, zap.NewNop()), RateLimit(RateLimitPolicy{RequestsPerMinute: 1, Burst: 1}, zap.NewNop()))
+	r.GET("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(key string) int {
+		req := httptest.NewRequest(http.MethodGet, "/analyze", nil)
+		req.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Both keys come from the same address but have their own buckets
+	assert.Equal(t, http.StatusOK, send("first"))
+	assert.Equal(t, http.StatusOK, send("second"))
+	assert.Equal(t, http.StatusTooManyRequests, send("first"))
+}
+
+func TestRateLimiter_Take(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	keys := stubKeyManager{keys: map[string]*domain.APIKey{"first": {ID: "k1", Role: domain.RoleClient}}}
+	limiter := NewRateLimiter(RateLimitPolicy{RequestsPerMinute: 60, Burst: 2}, zap.NewNop())
+	r := gin.New()
+	r.Use(Auth(keys, zap.NewNop()), limiter.Handler)
+	r.GET("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	client := ClientID(keys.keys["first"], "10.0.0.1")
+	assert.Equal(t, "key:k1", client)
+	assert.Equal(t, "ip:10.0.0.1", ClientID(nil, "10.0.0.1"))
+
+	assert.Zero(t, limiter.Take(client))
+	assert.Zero(t, limiter.Take(client))
+	assert.InDelta(t, time.Second, limiter.Take(client), float64(50*time.Millisecond))
+
+	// Other transports share the buckets of the REST API
+	req := httptest.NewRequest(http.MethodGet, "/analyze", nil)
+	req.Header.Set("X-API-Key", "first")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	limiter.Update(RateLimitPolicy{})
+	assert.Zero(t, limiter.Take(client))
+}
+
+func TestRateLimiter_Update(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter := NewRateLimiter(RateLimitPolicy{RequestsPerMinute: 1, Burst: 1}, zap.NewNop())
+	r := gin.New()
+	r.Use(limiter.Handler)
+	r.GET("/analyze", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/analyze", nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, send().Code)
+	assert.Equal(t, http.StatusTooManyRequests, send().Code)
+
+	// A new policy starts every client with a full bucket
+	limiter.Update(RateLimitPolicy{RequestsPerMinute: 60, Burst: 3})
+	w := send()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "3", w.Header().Get("RateLimit-Limit"))
+
+	// A zero rate disables the limit
+	limiter.Update(RateLimitPolicy{})
+	w = send()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimiter_FailureHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter := NewRateLimiter(RateLimitPolicy{Name: "auth_failures", RequestsPerMinute: 1, Burst: 2}, zap.NewNop())
+	r := gin.New()
+	r.Use(limiter.FailureHandler)
+	r.GET("/analyze", func(c *gin.Context) {
+		if c.GetHeader("X-API-Key") != "valid" {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	send := func(ip, key string) int {
+		req := httptest.NewRequest(http.MethodGet, "/analyze", nil)
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Successful requests are never counted
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, send("10.0.0.1", "valid"))
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, send("10.0.0.1", "guess-1"))
+	assert.Equal(t, http.StatusUnauthorized, send("10.0.0.1", "guess-2"))
+	assert.Equal(t, http.StatusTooManyRequests, send("10.0.0.1", "guess-3"))
+	// Once throttled, the address cannot try keys at all
+	assert.Equal(t, http.StatusTooManyRequests, send("10.0.0.1", "valid"))
+	assert.Equal(t, http.StatusUnauthorized, send("10.0.0.2", "guess-1"))
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID reuses a well-formed X-Request-ID from the client or generates a
+// new one, returns it in the response and attaches a logger tagged with it,
+// and with the trace ID when tracing is active, to the request context
+func RequestID(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !logging.ValidRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, id)
+
+		ctx := c.Request.Context()
+		fields := []zap.Field{zap.String("request_id", id)}
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+		}
+		ctx = logging.WithRequestID(ctx, id)
+		ctx = logging.WithLogger(ctx, logger.With(fields...))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/httperr"
+	"github.com/suraif16/webpage-analyzer/internal/logging"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		incoming   string
+		expectKeep bool
+	}{
+		{name: "Generated when missing"},
+		{name: "Client ID reused", incoming: "checkout-7f3a:42", expectKeep: true},
+		{name: "Unsafe ID replaced", incoming: "abc\r\nX-Injected: 1"},
+		{name: "Overlong ID replaced", incoming: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+
+			r := gin.New()
+			r.Use(RequestID(zap.New(core)), Logger(zap.NewNop()))
+			r.GET("/analyze", func(c *gin.Context) {
+				logging.FromContext(c.Request.Context(), zap.NewNop()).Info("handling")
+				httperr.Abort(c, domain.ErrInvalidURL)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/analyze", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeader)
+			if tt.expectKeep {
+				assert.Equal(t, tt.incoming, id)
+			} else {
+				_, err := uuid.Parse(id)
+				assert.NoError(t, err, "expected a generated UUID, got %q", id)
+			}
+
+			assert.Contains(t, w.Body.String(), `"requestId":"`+id+`"`)
+
+			// The handler log and the completion log share the ID
+			entries := logs.All()
+			if assert.Len(t, entries, 2) {
+				for _, e := range entries {
+					assert.Equal(t, id, e.ContextMap()["request_id"], e.Message)
+				}
+				assert.Equal(t, "request completed", entries[1].Message)
+			}
+		})
+	}
+}
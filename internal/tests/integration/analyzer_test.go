@@ -36,12 +36,13 @@ func setupRouter() *gin.Engine {
 	r := gin.New()
 
 	// Initialize dependencies with proper error handling
-	httpClient := httpclient.NewHTTPClient(10 * time.Second, logger)
+	httpClient := httpclient.NewHTTPClient(10*time.Second, logger)
 	htmlParser := parser.NewHTMLParser(logger)
-	analyzerService := services.NewAnalyzerService(httpClient, htmlParser, logger)
+	analyzerService := services.NewAnalyzerService(httpClient, htmlParser, services.LinkCheckPolicy{}, logger)
 	handler := handlers.NewAnalyzerHandler(analyzerService, logger)
 
 	r.POST("/analyze", handler.Analyze)
+	r.POST("/analyze/html", handler.AnalyzeHTML)
 	return r
 }
 
@@ -118,3 +119,36 @@ func TestIntegrationAnalyze(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegrationAnalyzeHTML(t *testing.T) {
+	router := setupRouter()
+
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Preview Build</title></head>
+<body>
+	<h1>Welcome</h1>
+	<a href="/pricing">Pricing</a>
+	<a href="https://external.example.org">External</a>
+	<form><input type="password" name="password"></form>
+</body>
+</html>`
+
+	req := httptest.NewRequest(http.MethodPost, "/analyze/html?baseUrl=https://staging.example.com", bytes.NewBufferString(html))
+	req.Header.Set("Content-Type", "text/html")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.PageAnalysis
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err, "Failed to unmarshal success response")
+	assert.Equal(t, "HTML5", response.HTMLVersion)
+	assert.Equal(t, "Preview Build", response.PageTitle)
+	assert.Equal(t, 1, response.Headings.H1)
+	assert.Equal(t, 1, response.Links.Internal)
+	assert.Equal(t, 1, response.Links.External)
+	assert.True(t, response.HasLoginForm)
+}
@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/suraif16/webpage-analyzer/internal/core/domain"
+	"github.com/suraif16/webpage-analyzer/internal/core/services"
+	httpclient "github.com/suraif16/webpage-analyzer/internal/infrastructure/http/client"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/metrics"
+	"github.com/suraif16/webpage-analyzer/internal/infrastructure/parser"
+	"go.uber.org/zap"
+)
+
+// TestIntegrationLinkCheckMetrics wires the analyzer like the server does and
+// checks that analyzing a page moves the link check metrics
+func TestIntegrationLinkCheckMetrics(t *testing.T) {
+	logger := zap.NewNop()
+
+	// Both links block until released, so both checks are in flight at once
+	var entered sync.WaitGroup
+	entered.Add(2)
+	release := make(chan struct{})
+	block := func(next http.HandlerFunc) http.HandlerFunc {
+		var once sync.Once
+		return func(w http.ResponseWriter, r *http.Request) {
+			once.Do(entered.Done)
+			<-release
+			next(w, r)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, `<!DOCTYPE html><html><head><title>Links</title></head><body>
+			<a href="/slow">Slow</a>
+			<a href="/missing">Missing</a>
+		</body></html>`)
+	})
+	mux.HandleFunc("/slow", block(func(w http.ResponseWriter, r *http.Request) {}))
+	mux.HandleFunc("/missing", block(http.NotFound))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	m := metrics.New()
+	client := metrics.InstrumentClient(httpclient.NewHTTPClient(5*time.Second, logger), m)
+	analyzer := services.NewAnalyzerService(client, parser.NewHTMLParser(logger),
+		services.LinkCheckPolicy{MaxLinks: 10, Concurrency: 2}, logger)
+
+	done := make(chan *domain.PageAnalysis)
+	go func() {
+		analysis, err := analyzer.Analyze(context.Background(), domain.AnalysisRequest{URL: server.URL})
+		assert.NoError(t, err)
+		done <- analysis
+	}()
+
+	checked := make(chan struct{})
+	go func() {
+		entered.Wait()
+		close(checked)
+	}()
+	select {
+	case <-checked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the links were never checked")
+	}
+	assert.Contains(t, scrapeMetrics(t, m), "webanalyzer_link_checks_in_flight 2")
+	close(release)
+
+	analysis := <-done
+	require.NotNil(t, analysis)
+	assert.Len(t, analysis.Links.Checks, 2)
+
+	body := scrapeMetrics(t, m)
+	assert.Contains(t, body, "webanalyzer_link_checks_in_flight 0")
+	assert.Contains(t, body, `webanalyzer_link_checks_total{status="reachable"} 1`)
+	assert.Contains(t, body, `webanalyzer_link_checks_total{status="broken"} 1`)
+}
+
+func scrapeMetrics(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return w.Body.String()
+}